@@ -0,0 +1,199 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrServiceDown is the error returned by calls made during an outage
+// without an error of its own.
+var ErrServiceDown = errors.New("mocks: service is down")
+
+// Clock tells the current time to scenarios.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock whose time only changes when it is advanced, which
+// makes time based outages deterministic.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a new fake clock starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Outage is a window during which the mocked service is down, making the
+// calls that would match an expectation fail instead. An outage without any
+// window lasts forever.
+type Outage struct {
+	// FromCall and ToCall set the window by the position of the calls, made
+	// to any method after the scenario was created, starting at 1. Both
+	// ends are included. If ToCall is zero, the outage never ends.
+	FromCall int
+	ToCall   int
+
+	// From and To set the window by the time elapsed, according to the
+	// scenario clock, since the scenario was created. To is excluded. If To
+	// is zero, the outage never ends.
+	From time.Duration
+	To   time.Duration
+
+	// Methods limits the outage to some methods, by name. If empty, all
+	// methods are affected.
+	Methods []string
+
+	// Error is the error returned by the calls. If nil, ErrServiceDown is
+	// used.
+	Error error
+}
+
+// Scenario describes the outages of a mocked service over its calls.
+// Outages are layered over the expectations set up through Mock: calls must
+// still match an expectation, but return the outage error while it lasts.
+type Scenario struct {
+	mu      sync.Mutex
+	clock   Clock
+	start   time.Time
+	first   int
+	outages []Outage
+	hits    int
+}
+
+// Scenario returns a new scenario for the mock client, with no outages, that
+// uses the real clock.
+//
+// Example:
+//
+//	mock.Scenario().
+//		DownForCalls(3, 7, status.Error(codes.Unavailable, "down")).
+//		DownFor(0, 200*time.Millisecond, nil)
+func (m *MockServiceClient[R, T]) Scenario() *Scenario {
+	s := &Scenario{
+		clock: realClock{},
+		start: time.Now(),
+		first: m.tracker.totalCalls(),
+	}
+
+	m.tracker.use(s.perturb)
+	return s
+}
+
+// WithClock sets the clock of the scenario, restarting its time windows.
+func (s *Scenario) WithClock(clock Clock) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.clock = clock
+	s.start = clock.Now()
+	return s
+}
+
+// Outage adds an outage to the scenario.
+func (s *Scenario) Outage(o Outage) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outages = append(s.outages, o)
+	return s
+}
+
+// DownForCalls makes the calls from the from-th to the to-th, inclusive,
+// fail with err.
+func (s *Scenario) DownForCalls(from, to int, err error) *Scenario {
+	return s.Outage(Outage{FromCall: from, ToCall: to, Error: err})
+}
+
+// DownFor makes the calls made between from and to, since the scenario was
+// created, fail with err.
+func (s *Scenario) DownFor(from, to time.Duration, err error) *Scenario {
+	return s.Outage(Outage{From: from, To: to, Error: err})
+}
+
+// Hits returns how many calls failed because of the outages.
+func (s *Scenario) Hits() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.hits
+}
+
+func (s *Scenario) perturb(inv *invocation) {
+	if !inv.canFail() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	call := inv.index - s.first
+	elapsed := s.clock.Now().Sub(s.start)
+
+	for _, o := range s.outages {
+		if !o.affects(inv.method, call, elapsed) {
+			continue
+		}
+
+		err := o.Error
+		if err == nil {
+			err = ErrServiceDown
+		}
+
+		s.hits++
+		inv.fail(err)
+		return
+	}
+}
+
+func (o Outage) affects(method string, call int, elapsed time.Duration) bool {
+	if len(o.Methods) > 0 {
+		found := false
+		for _, m := range o.Methods {
+			found = found || m == method
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	byCall := o.FromCall > 0 || o.ToCall > 0
+	if byCall && (call < o.FromCall || (o.ToCall > 0 && call > o.ToCall)) {
+		return false
+	}
+
+	byTime := o.From > 0 || o.To > 0
+	if byTime && (elapsed < o.From || (o.To > 0 && elapsed >= o.To)) {
+		return false
+	}
+
+	return true
+}
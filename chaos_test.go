@@ -0,0 +1,123 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestScenario(t *testing.T) {
+	t.Run("should be down for a range of calls across all methods", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			Return:   "Mocked Output",
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByInt,
+			AnyTimes: true,
+			Return:   42,
+		})
+
+		unavailable := status.Error(codes.Unavailable, "down")
+		scenario := mock.Scenario().DownForCalls(3, 7, unavailable)
+
+		var failed []int
+		for i := 1; i <= 10; i++ {
+			var err error
+			if i%2 == 0 {
+				_, err = mock.Client().GetByString(ctx, "Hello World")
+			} else {
+				_, err = mock.Client().GetByInt(ctx, i)
+			}
+
+			if err != nil {
+				a.Equal(codes.Unavailable, status.Code(err))
+				failed = append(failed, i)
+			}
+		}
+
+		a.Equal([]int{3, 4, 5, 6, 7}, failed)
+		a.Equal(5, scenario.Hits())
+	})
+
+	t.Run("should be down for a time window of the fake clock", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			Return:   "Mocked Output",
+		})
+
+		clock := NewFakeClock(time.Now())
+		mock.Scenario().
+			WithClock(clock).
+			DownFor(0, 200*time.Millisecond, nil)
+
+		_, err := mock.Client().GetByString(ctx, "Hello World")
+		a.ErrorIs(err, ErrServiceDown)
+
+		clock.Advance(199 * time.Millisecond)
+		_, err = mock.Client().GetByString(ctx, "Hello World")
+		a.ErrorIs(err, ErrServiceDown)
+
+		clock.Advance(time.Millisecond)
+		output, err := mock.Client().GetByString(ctx, "Hello World")
+		a.NoError(err)
+		a.Equal("Mocked Output", output)
+	})
+
+	t.Run("should limit outages to some methods", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Times:  1,
+			Return: 42,
+		})
+
+		mock.Scenario().Outage(Outage{Methods: []string{"GetByInt"}})
+
+		output, err := mock.Client().GetByString(ctx, "Hello World")
+		a.NoError(err)
+		a.Equal("Mocked Output", output)
+
+		_, err = mock.Client().GetByInt(ctx, 1)
+		a.ErrorIs(err, ErrServiceDown)
+	})
+}
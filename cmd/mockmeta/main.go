@@ -0,0 +1,257 @@
+// Command mockmeta generates the metadata of the interfaces of a source
+// file, such as the names of their parameters, for the mocks generated from
+// it by mockgen. It is run with the same arguments:
+//
+//	mockmeta -source=internal/example/example.go -destination=internal/example/mock/example_metadata.go -package mock_example
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/somatech1/mocks/metadata"
+)
+
+var (
+	source      = flag.String("source", "", "source file of the mocked interfaces")
+	destination = flag.String("destination", "", "output file, or the standard output if empty")
+	packageName = flag.String("package", "", "package of the generated mocks, mock_ followed by the source package by default")
+)
+
+func main() {
+	flag.Parse()
+
+	if *source == "" {
+		fmt.Fprintln(os.Stderr, "mockmeta: -source is required")
+		os.Exit(2)
+	}
+
+	if err := run(*source, *destination, *packageName); err != nil {
+		fmt.Fprintf(os.Stderr, "mockmeta: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(source, destination, pkg string) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, source, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+
+	if pkg == "" {
+		pkg = "mock_" + f.Name.Name
+	}
+
+	out, err := generate(fset, f, source, pkg)
+	if err != nil {
+		return err
+	}
+
+	if destination == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(destination, out, 0o644)
+}
+
+// mocked is an interface of the source file, along with its generated mock.
+type mocked struct {
+	metadata.Interface
+
+	// Receiver is the receiver of the generated mock methods, with the type
+	// parameters of generic interfaces.
+	Receiver string
+
+	// Var is the package variable holding the metadata.
+	Var string
+}
+
+var fileTemplate = template.Must(template.New("metadata").Parse(`// Code generated by mockmeta. DO NOT EDIT.
+// Source: {{.Source}}
+
+package {{.Package}}
+{{if .Interfaces}}
+import "github.com/somatech1/mocks/metadata"
+{{end}}
+{{- range .Interfaces}}
+// MockMetadata returns the metadata of the {{.Name}} interface.
+func (m {{.Receiver}}) MockMetadata() *metadata.Interface {
+	return {{.Var}}
+}
+
+var {{.Var}} = &metadata.Interface{
+	Name: {{printf "%q" .Name}},
+	Doc:  {{printf "%q" .Doc}},
+	Methods: []metadata.Method{
+{{- range .Methods}}
+		{
+			Name:     {{printf "%q" .Name}},
+			Doc:      {{printf "%q" .Doc}},
+			Variadic: {{.Variadic}},
+			Params: []metadata.Param{
+{{- range .Params}}
+				{Name: {{printf "%q" .Name}}, Type: {{printf "%q" .Type}}},
+{{- end}}
+			},
+			Results: []metadata.Param{
+{{- range .Results}}
+				{Name: {{printf "%q" .Name}}, Type: {{printf "%q" .Type}}},
+{{- end}}
+			},
+		},
+{{- end}}
+	},
+}
+{{end}}`))
+
+func generate(fset *token.FileSet, f *ast.File, source, pkg string) ([]byte, error) {
+	var interfaces []mocked
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			it, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || ts.Assign.IsValid() {
+				continue
+			}
+
+			comment := ts.Doc
+			if comment == nil && len(gd.Specs) == 1 {
+				comment = gd.Doc
+			}
+
+			m := mocked{
+				Interface: metadata.Interface{
+					Name: ts.Name.Name,
+					Doc:  text(comment),
+				},
+				Receiver: "*Mock" + ts.Name.Name + typeParams(ts),
+				Var:      lowerFirst(ts.Name.Name) + "Metadata",
+			}
+
+			for _, field := range it.Methods.List {
+				ft, ok := field.Type.(*ast.FuncType)
+				if !ok || len(field.Names) == 0 {
+					// Embedded interfaces are not followed.
+					continue
+				}
+
+				m.Methods = append(m.Methods, method(fset, field.Names[0].Name, text(field.Doc), ft))
+			}
+
+			interfaces = append(interfaces, m)
+		}
+	}
+
+	var b bytes.Buffer
+	err := fileTemplate.Execute(&b, map[string]interface{}{
+		"Source":     filepath.ToSlash(source),
+		"Package":    pkg,
+		"Interfaces": interfaces,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(b.Bytes())
+}
+
+func method(fset *token.FileSet, name, comment string, ft *ast.FuncType) metadata.Method {
+	m := metadata.Method{
+		Name: name,
+		Doc:  comment,
+	}
+
+	// Unnamed parameters are named like mockgen does.
+	for _, field := range ft.Params.List {
+		typ := expr(fset, field.Type)
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			m.Variadic = true
+		}
+
+		if len(field.Names) == 0 {
+			m.Params = append(m.Params, metadata.Param{Name: fmt.Sprintf("arg%d", len(m.Params)), Type: typ})
+			continue
+		}
+
+		for _, n := range field.Names {
+			m.Params = append(m.Params, metadata.Param{Name: n.Name, Type: typ})
+		}
+	}
+
+	if ft.Results == nil {
+		return m
+	}
+
+	for _, field := range ft.Results.List {
+		typ := expr(fset, field.Type)
+		if len(field.Names) == 0 {
+			m.Results = append(m.Results, metadata.Param{Type: typ})
+			continue
+		}
+
+		for _, n := range field.Names {
+			m.Results = append(m.Results, metadata.Param{Name: n.Name, Type: typ})
+		}
+	}
+
+	return m
+}
+
+// typeParams returns the type parameters of a generic interface as written
+// in the receiver of its mock methods, such as [K, V].
+func typeParams(ts *ast.TypeSpec) string {
+	if ts.TypeParams == nil {
+		return ""
+	}
+
+	var names []string
+	for _, field := range ts.TypeParams.List {
+		for _, n := range field.Names {
+			names = append(names, n.Name)
+		}
+	}
+
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func expr(fset *token.FileSet, e ast.Expr) string {
+	var b bytes.Buffer
+	_ = printer.Fprint(&b, fset, e)
+	return b.String()
+}
+
+func text(comment *ast.CommentGroup) string {
+	if comment == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(comment.Text())
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
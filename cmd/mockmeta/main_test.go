@@ -0,0 +1,78 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("should match the generated metadata of the examples", func(t *testing.T) {
+		a := assert.New(t)
+		root := filepath.Join("..", "..")
+
+		for _, name := range []string{"example", "repository", "store", "publisher"} {
+			source := "internal/example/" + name + ".go"
+
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, filepath.Join(root, source), nil, parser.ParseComments|parser.SkipObjectResolution)
+			a.NoError(err)
+
+			got, err := generate(fset, f, source, "mock_example")
+			a.NoError(err)
+
+			want, err := os.ReadFile(filepath.Join(root, "internal/example/mock", name+"_metadata.go"))
+			a.NoError(err)
+			a.Equal(string(want), string(got), "run generate_mock.sh to update %s", name)
+		}
+	})
+
+	t.Run("should name unnamed parameters like mockgen", func(t *testing.T) {
+		a := assert.New(t)
+
+		src := `package svc
+
+import "context"
+
+type Service interface {
+	Do(context.Context, string, ...int) (n int, err error)
+}
+`
+
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "svc.go", src, parser.ParseComments)
+		a.NoError(err)
+
+		out, err := generate(fset, f, "svc.go", "mock_svc")
+		a.NoError(err)
+		a.Contains(string(out), `{Name: "arg0", Type: "context.Context"}`)
+		a.Contains(string(out), `{Name: "arg2", Type: "...int"}`)
+		a.Contains(string(out), `{Name: "n", Type: "int"}`)
+		a.Contains(string(out), "Variadic: true")
+		a.Contains(string(out), "func (m *MockService) MockMetadata() *metadata.Interface")
+	})
+	t.Run("should not import the metadata without interfaces", func(t *testing.T) {
+		a := assert.New(t)
+
+		src := `package svc
+
+type Service struct{}
+`
+
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "svc.go", src, parser.ParseComments)
+		a.NoError(err)
+
+		out, err := generate(fset, f, "svc.go", "mock_svc")
+		a.NoError(err)
+
+		generated, err := parser.ParseFile(token.NewFileSet(), "svc_metadata.go", out, 0)
+		a.NoError(err)
+		a.Empty(generated.Imports)
+		a.Equal("mock_svc", generated.Name.Name)
+	})
+}
@@ -0,0 +1,172 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+// These tests are meant to be run with -race.
+
+func TestConcurrency(t *testing.T) {
+	t.Run("should count calls made from multiple goroutines", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		calls := 100
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Times:  calls,
+			Return: 42,
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < calls; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				output, err := mock.Client().GetByInt(ctx, i)
+				a.NoError(err)
+				a.Equal(42, output)
+			}(i)
+		}
+
+		wg.Wait()
+		a.Equal(calls, mock.Calls("GetByInt"))
+	})
+
+	t.Run("should set up expectations while other goroutines call the mock", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		opts := &MockOptions{
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			Return:   "Mocked Output",
+		}
+		mock.Mock(opts)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+
+				output, err := mock.Client().GetByString(ctx, "Hello World")
+				a.NoError(err)
+				a.Equal("Mocked Output", output)
+			}()
+
+			go func(i int) {
+				defer wg.Done()
+
+				// the same options can be shared between goroutines
+				mock.Mock(opts)
+				mock.Mock(&MockOptions{
+					Ctx:    ctx,
+					Call:   mock.Recorder().GetByInt,
+					Times:  1,
+					Input:  i,
+					Return: i,
+				})
+
+				output, err := mock.Client().GetByInt(ctx, i)
+				a.NoError(err)
+				a.Equal(i, output)
+			}(i)
+		}
+
+		wg.Wait()
+		a.Equal(50, mock.Calls("GetByInt"))
+	})
+
+	t.Run("should wait for calls made by background goroutines", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  3,
+			Return: "Mocked Output",
+		})
+
+		for i := 0; i < 3; i++ {
+			go func(i int) {
+				time.Sleep(time.Duration(i) * 10 * time.Millisecond)
+				_, _ = mock.Client().GetByString(ctx, fmt.Sprint(i))
+			}(i)
+		}
+
+		a.NoError(mock.WaitForCalls("GetByString", 3, time.Second))
+		a.Equal(3, mock.Calls("GetByString"))
+	})
+
+	t.Run("should time out waiting for calls that are not made", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		err := mock.WaitForCalls("GetByString", 1, 10*time.Millisecond)
+
+		a.Error(err)
+		a.Contains(err.Error(), "waiting for 1 call(s) to GetByString, got 0")
+	})
+
+	t.Run("should support parallel subtests", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			i := i
+			t.Run(fmt.Sprintf("subtest %d", i), func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.TODO()
+				a := assert.New(t)
+
+				mock := New(
+					t,
+					example_mock.NewMockExampleMock,
+				)
+
+				mock.Mock(&MockOptions{
+					Ctx:    ctx,
+					Call:   mock.Recorder().GetByInt,
+					Times:  1,
+					Input:  i,
+					Return: i * 2,
+				})
+
+				output, err := mock.Client().GetByInt(ctx, i)
+
+				a.NoError(err)
+				a.Equal(i*2, output)
+			})
+		}
+	})
+}
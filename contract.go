@@ -0,0 +1,206 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+// ContractOptions sets how the calls made to a mock client are replayed
+// against a real implementation by VerifyContract.
+type ContractOptions struct {
+	// Ctx is the context given to the replayed calls, instead of the ones
+	// received by the mock client, which may be done by then. It is
+	// context.Background() by default.
+	Ctx context.Context
+
+	// Methods limits the replayed calls to the ones made to these methods.
+	// All calls are replayed by default.
+	Methods []string
+
+	// IgnoreFields lists the fields of the results that are not compared,
+	// like MockOptions.IgnoreFields, such as generated ids or timestamps.
+	IgnoreFields []string
+}
+
+// VerifyContract replays the calls made to the mock client so far, in
+// order and with the same arguments, against real, an implementation of the
+// mocked interface such as a client of a local server. It returns an error
+// describing every call whose real results differ from the mocked ones, or
+// nil if they all match.
+//
+// The expectations that were never called are replayed afterwards, with
+// their inputs, when they return the results set up with Return. The ones
+// matching any value for some argument, set up with DoAndReturn, Panic or
+// Generator, or whose group was cleared or disabled, can not be replayed
+// and are not verified until they are called.
+//
+// Errors are compared by their gRPC status codes, as the messages of mocked
+// errors are seldom the real ones, and the other results are compared only
+// when both calls succeeded. The results changed by fault injection or
+// outages are not taken into account, and calls that panicked are skipped.
+//
+// Example:
+//
+//	require.NoError(t, mock.VerifyContract(server.Client(), nil))
+func (m *MockServiceClient[R, T]) VerifyContract(real interface{}, opts *ContractOptions) error {
+	if opts == nil {
+		opts = &ContractOptions{}
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tr := m.tracker
+
+	tr.mu.Lock()
+	log := append([]*invocation{}, tr.log...)
+	expectations := append([]*Expectation{}, tr.expectations...)
+	tr.mu.Unlock()
+
+	sort.Slice(log, func(i, j int) bool {
+		return log[i].index < log[j].index
+	})
+
+	var (
+		replayed int
+		differ   []string
+	)
+
+	verify := func(title string, inv *invocation) {
+		if len(opts.Methods) > 0 && indexOf(opts.Methods, inv.method) < 0 {
+			return
+		}
+
+		replayed++
+		lines := replay(ctx, reflect.ValueOf(real), inv, opts.IgnoreFields)
+		if len(lines) == 0 {
+			return
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "  - %s set up at %s:\n", title, inv.site)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "      %s\n", line)
+		}
+
+		differ = append(differ, b.String())
+	}
+
+	for i, inv := range log {
+		if !inv.panicked {
+			verify(fmt.Sprintf("%d. %s", i+1, inv.formatCall()), inv)
+		}
+	}
+
+	for _, e := range expectations {
+		if inv, ok := e.expected(); ok {
+			verify(fmt.Sprintf("never called %s", inv.formatCall()), inv)
+		}
+	}
+
+	if len(differ) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"mocks: %d of %d call(s) to %T differ from %T:\n%s",
+		len(differ), replayed, tr.client, real, strings.Join(differ, ""),
+	)
+}
+
+// replay makes the call against the method of real, returning how its
+// results differ from the mocked ones.
+func replay(ctx context.Context, real reflect.Value, inv *invocation, ignore []string) (lines []string) {
+	method := real.MethodByName(inv.method)
+	if !method.IsValid() {
+		return []string{fmt.Sprintf("%s is not implemented by %v", inv.method, real.Type())}
+	}
+
+	mt := method.Type()
+	if mt != inv.methodType {
+		return []string{fmt.Sprintf("%s has type %v, mocked %v", inv.method, mt, inv.methodType)}
+	}
+
+	// The arguments are converted with the deferred recover installed, so
+	// that mismatched ones are reported like the panics of the call.
+	defer func() {
+		if r := recover(); r != nil {
+			lines = []string{fmt.Sprintf("panicked: %v", r)}
+		}
+	}()
+
+	args := make([]reflect.Value, len(inv.args))
+	for i, arg := range inv.args {
+		switch {
+		case mt.In(i) == contextType:
+			args[i] = reflect.ValueOf(ctx)
+		case arg == nil:
+			args[i] = reflect.Zero(mt.In(i))
+		default:
+			args[i] = reflect.New(mt.In(i)).Elem()
+			args[i].Set(reflect.ValueOf(arg))
+		}
+	}
+
+	var out []reflect.Value
+	if mt.IsVariadic() {
+		out = method.CallSlice(args)
+	} else {
+		out = method.Call(args)
+	}
+
+	got := make([]interface{}, len(out))
+	for i, v := range out {
+		got[i] = v.Interface()
+	}
+
+	return contractDiff(inv, got, ignore)
+}
+
+// contractDiff compares the real results of a call with the mocked ones.
+func contractDiff(inv *invocation, got []interface{}, ignore []string) []string {
+	want := inv.mocked
+
+	if inv.canFail() {
+		last := len(want) - 1
+		wantErr, _ := want[last].(error)
+		gotErr, _ := got[last].(error)
+
+		switch {
+		case wantErr == nil && gotErr != nil:
+			return []string{fmt.Sprintf("error: want nil, got %v", gotErr)}
+		case wantErr != nil && gotErr == nil:
+			return []string{fmt.Sprintf("error: want %v, got nil", wantErr)}
+		case wantErr != nil:
+			if status.Code(wantErr) != status.Code(gotErr) {
+				return []string{fmt.Sprintf("error: want code %v, got %v (%v)", status.Code(wantErr), status.Code(gotErr), gotErr)}
+			}
+
+			return nil
+		}
+	}
+
+	// Results are compared with their types, so that mocked nil values are
+	// equal to typed nil ones.
+	wantValues := returnValuesOf(inv.methodType, want)
+
+	var lines []string
+	for i, w := range wantValues {
+		if inv.canFail() && i == len(wantValues)-1 {
+			continue
+		}
+
+		for _, d := range diffLines(w.Interface(), got[i], ignore) {
+			lines = append(lines, fmt.Sprintf("result %d: %s", i, d))
+		}
+	}
+
+	return lines
+}
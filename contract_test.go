@@ -0,0 +1,230 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+// realExample is the real implementation of some methods of the example
+// interface.
+type realExample struct{}
+
+func (realExample) GetByString(_ context.Context, str string) (string, error) {
+	return strings.ToUpper(str), nil
+}
+
+func (realExample) GetByInt(_ context.Context, i int) (int, error) {
+	if i < 0 {
+		return 0, status.Error(codes.InvalidArgument, "negative")
+	}
+
+	return i * 2, nil
+}
+
+func (realExample) WithStruct(_ context.Context, in *example.Example) (*example.Example, error) {
+	return &example.Example{Id: in.Id, Value: "real"}, nil
+}
+
+func (realExample) GetWithVariadic(_ context.Context, _ string, options ...string) (int, error) {
+	return len(options), nil
+}
+
+func TestVerifyContract(t *testing.T) {
+	t.Run("should accept calls whose real results match the mocked ones", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Input:  "hello",
+			Times:  1,
+			Return: "HELLO",
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Input:  -1,
+			Times:  1,
+			Return: 0,
+			Error:  status.Error(codes.InvalidArgument, "mocked message"),
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    "id",
+			Variadic: VariadicAny(),
+			Times:    1,
+			Return:   2,
+		})
+
+		_, _ = mock.Client().GetByString(ctx, "hello")
+		_, _ = mock.Client().GetByInt(ctx, -1)
+		_, _ = mock.Client().GetWithVariadic(ctx, "id", "a", "b")
+
+		a.NoError(mock.VerifyContract(realExample{}, nil))
+	})
+
+	t.Run("should describe the calls whose real results differ", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Input:  2,
+			Times:  1,
+			Return: 5,
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().WithStruct,
+			Times:  1,
+			Return: &example.Example{Id: "1", Value: "mocked"},
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "",
+			Error:  errors.New("mocks: service is down"),
+		})
+
+		_, _ = mock.Client().GetByInt(ctx, 2)
+		_, _ = mock.Client().WithStruct(ctx, &example.Example{Id: "1"})
+		_, _ = mock.Client().GetByString(ctx, "x")
+
+		err := mock.VerifyContract(realExample{}, nil)
+		a.ErrorContains(err, "mocks: 3 of 3 call(s) to *mock_example.MockExampleMock differ from mocks.realExample:")
+		a.ErrorContains(err, "  - 1. GetByInt(ctx, 2) set up at contract_test.go:")
+		a.ErrorContains(err, "      result 0: value: want 5, got 4")
+		a.ErrorContains(err, `      result 0: Value: want "mocked", got "real"`)
+		a.ErrorContains(err, "      error: want mocks: service is down, got nil")
+	})
+
+	t.Run("should only replay the calls to the methods", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Times:  1,
+			Return: 5,
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().WithStruct,
+			Times:  1,
+			Return: &example.Example{Id: "1", Value: "mocked"},
+		})
+
+		_, _ = mock.Client().GetByInt(ctx, 2)
+		_, _ = mock.Client().WithStruct(ctx, &example.Example{Id: "1"})
+
+		a.NoError(mock.VerifyContract(realExample{}, &ContractOptions{
+			Methods:      []string{"WithStruct"},
+			IgnoreFields: []string{"Value"},
+		}))
+	})
+
+	t.Run("should replay the expectations that were never called", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByInt,
+			Input:    3,
+			AnyTimes: true,
+			Return:   6,
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			Input:    "hello",
+			AnyTimes: true,
+			Return:   "hello",
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    []interface{}{"id", "a", "b"},
+			AnyTimes: true,
+			Return:   2,
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().WithStruct,
+			AnyTimes: true,
+			Return:   &example.Example{Id: "1", Value: "mocked"},
+		})
+
+		err := mock.VerifyContract(realExample{}, nil)
+		a.ErrorContains(err, "mocks: 1 of 3 call(s) to *mock_example.MockExampleMock differ from mocks.realExample:")
+		a.ErrorContains(err, `  - never called GetByString(ctx, "hello") set up at contract_test.go:`)
+		a.ErrorContains(err, `      result 0: value: want "hello", got "HELLO"`)
+	})
+
+	t.Run("should report arguments that do not fit the real method", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		real := reflect.ValueOf(realExample{})
+		inv := &invocation{
+			method:     "GetByInt",
+			methodType: real.MethodByName("GetByInt").Type(),
+			args:       []interface{}{ctx, "2"},
+		}
+
+		a.Equal(
+			[]string{"panicked: reflect.Set: value of type string is not assignable to type int"},
+			replay(ctx, real, inv, nil),
+		)
+	})
+
+	t.Run("should report methods missing from the real implementation", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:                 ctx,
+			Call:                mock.Recorder().SingleError,
+			Input:               "id",
+			Times:               1,
+			SingleErrorReturned: true,
+		})
+
+		_ = mock.Client().SingleError(ctx, "id")
+
+		err := mock.VerifyContract(realExample{}, nil)
+		a.ErrorContains(err, "SingleError is not implemented by mocks.realExample")
+	})
+}
@@ -0,0 +1,210 @@
+package mocks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// coverage records, for every mocked interface, which of its methods had
+// expectations and which were called, across the tests of a test binary.
+type coverage struct {
+	mu         sync.Mutex
+	interfaces map[string]*interfaceCoverage
+}
+
+type interfaceCoverage struct {
+	methods []string
+	mocked  map[string]int
+	called  map[string]int
+}
+
+// suiteCoverage is the coverage of the running test binary, written by
+// RunWithCoverage.
+var suiteCoverage = newCoverage()
+
+func newCoverage() *coverage {
+	return &coverage{
+		interfaces: make(map[string]*interfaceCoverage),
+	}
+}
+
+// RunWithCoverage runs the tests of m, usually a *testing.M, then writes to
+// path which methods of the mocked interfaces were never mocked, or never
+// called, by the tests. It returns the exit code of the tests, or 1 if the
+// report could not be written, so it is used from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(mocks.RunWithCoverage(m, "mocks.coverage"))
+//	}
+//
+// As go test runs each package on its own, with the package directory as
+// working directory, every package writes its own report.
+func RunWithCoverage(m interface{ Run() int }, path string) int {
+	code := m.Run()
+
+	if err := suiteCoverage.write(path); err != nil {
+		fmt.Fprintf(os.Stderr, "mocks: could not write the coverage report: %v\n", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	return code
+}
+
+// register adds the methods of the interface mocked by the client.
+func (c *coverage) register(client interface{}) *interfaceCoverage {
+	name := interfaceName(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ic, ok := c.interfaces[name]; ok {
+		return ic
+	}
+
+	ic := &interfaceCoverage{
+		methods: interfaceMethods(client),
+		mocked:  make(map[string]int),
+		called:  make(map[string]int),
+	}
+
+	c.interfaces[name] = ic
+	return ic
+}
+
+func (c *coverage) mock(client interface{}, method string) {
+	ic := c.register(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ic.mocked[method]++
+}
+
+func (c *coverage) call(client interface{}, method string) {
+	ic := c.register(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ic.called[method]++
+}
+
+// report lists, for every mocked interface, the methods that were never
+// mocked, then the ones that were mocked but never called, and finally the
+// ones that were called.
+func (c *coverage) report() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.interfaces))
+	total, unmocked := 0, 0
+	for name, ic := range c.interfaces {
+		names = append(names, name)
+		total += len(ic.methods)
+		for _, m := range ic.methods {
+			if ic.mocked[m] == 0 {
+				unmocked++
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "mocks coverage: %d of %d method(s) never mocked\n", unmocked, total)
+
+	for _, name := range names {
+		ic := c.interfaces[name]
+
+		var never, uncalled, called []string
+		for _, m := range ic.methods {
+			switch {
+			case ic.mocked[m] == 0:
+				never = append(never, m)
+			case ic.called[m] == 0:
+				uncalled = append(uncalled, m)
+			default:
+				called = append(called, m)
+			}
+		}
+
+		fmt.Fprintf(&b, "\n%s: %d of %d method(s) mocked\n", name, len(ic.methods)-len(never), len(ic.methods))
+		for _, m := range never {
+			fmt.Fprintf(&b, "  - %s: never mocked\n", m)
+		}
+
+		for _, m := range uncalled {
+			fmt.Fprintf(&b, "  - %s: mocked %d time(s), never called\n", m, ic.mocked[m])
+		}
+
+		for _, m := range called {
+			fmt.Fprintf(&b, "  - %s: mocked %d time(s), called %d time(s)\n", m, ic.mocked[m], ic.called[m])
+		}
+	}
+
+	return b.String()
+}
+
+func (c *coverage) write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(c.report()), 0o644)
+}
+
+// interfaceNamer is implemented by the mock clients whose type arguments
+// tell apart the interfaces they mock.
+type interfaceNamer interface {
+	interfaceName() string
+}
+
+// interfaceName returns the name of the mock client in the report. The type
+// arguments of a generic mock are dropped, as MockStore[int] and
+// MockStore[string] mock the same Store interface.
+func interfaceName(client interface{}) string {
+	if n, ok := client.(interfaceNamer); ok {
+		return n.interfaceName()
+	}
+
+	name := fmt.Sprintf("%T", client)
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// methodLister is implemented by the mock clients whose methods are not
+// methods of their types.
+type methodLister interface {
+	methodNames() []string
+}
+
+// interfaceMethods returns the names of the methods of the interface mocked
+// by the client, which are the methods of its recorder.
+func interfaceMethods(client interface{}) []string {
+	if l, ok := client.(methodLister); ok {
+		return l.methodNames()
+	}
+
+	expect := reflect.ValueOf(client).MethodByName("EXPECT")
+	if !expect.IsValid() || expect.Type().NumIn() != 0 || expect.Type().NumOut() != 1 {
+		return nil
+	}
+
+	recorder := expect.Type().Out(0)
+	names := make([]string, recorder.NumMethod())
+	for i := range names {
+		names[i] = recorder.Method(i).Name
+	}
+
+	return names
+}
@@ -0,0 +1,129 @@
+package mocks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+// coverageRunner runs no tests, returning its exit code.
+type coverageRunner int
+
+func (r coverageRunner) Run() int {
+	return int(r)
+}
+
+func TestCoverage(t *testing.T) {
+	t.Run("should list the methods never mocked and never called", func(t *testing.T) {
+		a := assert.New(t)
+		c := newCoverage()
+		client := example_mock.NewMockExampleRepository(gomock.NewController(t))
+
+		c.register(client)
+		c.mock(client, "GetExample")
+		c.mock(client, "GetExample")
+		c.call(client, "GetExample")
+		c.mock(client, "DeleteExample")
+
+		a.Equal(`mocks coverage: 3 of 5 method(s) never mocked
+
+*mock_example.MockExampleRepository: 2 of 5 method(s) mocked
+  - CreateExample: never mocked
+  - ListExamples: never mocked
+  - UpdateExample: never mocked
+  - DeleteExample: mocked 1 time(s), never called
+  - GetExample: mocked 2 time(s), called 1 time(s)
+`, c.report())
+	})
+
+	t.Run("should merge the instances of a generic mock", func(t *testing.T) {
+		a := assert.New(t)
+		c := newCoverage()
+		ctrl := gomock.NewController(t)
+		ints := example_mock.NewMockStore[int](ctrl)
+		strs := example_mock.NewMockStore[string](ctrl)
+
+		c.mock(ints, "Get")
+		c.mock(strs, "Get")
+		c.call(strs, "Get")
+
+		a.Equal(`mocks coverage: 3 of 4 method(s) never mocked
+
+*mock_example.MockStore: 1 of 4 method(s) mocked
+  - List: never mocked
+  - Put: never mocked
+  - Save: never mocked
+  - Get: mocked 2 time(s), called 1 time(s)
+`, c.report())
+	})
+
+	t.Run("should tell apart the interfaces of dynamic mocks", func(t *testing.T) {
+		a := assert.New(t)
+		c := newCoverage()
+		ctrl := gomock.NewController(t)
+
+		c.register(NewDynamic[example.ExampleRepository](ctrl))
+		c.register(NewDynamic[example.Publisher](ctrl))
+
+		report := c.report()
+		a.Contains(report, "\n*mocks.Dynamic[example.ExampleRepository]: 0 of 5 method(s) mocked\n")
+		a.Contains(report, "\n*mocks.Dynamic[example.Publisher]: 0 of 1 method(s) mocked\n")
+	})
+
+	t.Run("should record the expectations and calls of mock clients", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		type covered interface {
+			Covered(ctx context.Context) error
+			Uncovered(ctx context.Context) error
+		}
+
+		mock := New(
+			t,
+			NewDynamic[covered],
+		)
+
+		// A coverage of its own keeps the counts of other runs of the test
+		// out of the report.
+		c := newCoverage()
+		mock.tracker.coverage = c
+
+		mock.Mock(&MockOptions{
+			Ctx:                 ctx,
+			Call:                mock.Recorder().Method("Covered"),
+			Times:               1,
+			SingleErrorReturned: true,
+		})
+
+		mock.Client().Call("Covered", ctx)
+
+		report := c.report()
+		a.Contains(report, "  - Covered: mocked 1 time(s), called 1 time(s)\n")
+		a.Contains(report, "  - Uncovered: never mocked\n")
+	})
+
+	t.Run("should write the report after running the tests", func(t *testing.T) {
+		a := assert.New(t)
+		path := filepath.Join(t.TempDir(), "reports", "mocks.coverage")
+
+		a.Equal(3, RunWithCoverage(coverageRunner(3), path))
+
+		report, err := os.ReadFile(path)
+		a.NoError(err)
+		a.Contains(string(report), "mocks coverage: ")
+	})
+
+	t.Run("should fail when the report can not be written", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.Equal(1, RunWithCoverage(coverageRunner(0), t.TempDir()))
+	})
+}
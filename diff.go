@@ -0,0 +1,264 @@
+package mocks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	timeType         = reflect.TypeOf(time.Time{})
+)
+
+// Diff returns a structural description of the differences between want
+// and got, with one line per different field, or an empty string if they
+// are equal.
+//
+// Nested structs, pointers, slices, maps and protobuf messages are walked,
+// including unexported fields, except for the internal state of protobuf
+// messages. Fields listed in ignore are not compared. They can be given by
+// name, matching the field at any depth, or by path, i.e, "Meta.CreatedAt".
+func Diff(want, got interface{}, ignore ...string) string {
+	return strings.Join(diffLines(want, got, ignore), "\n")
+}
+
+func diffLines(want, got interface{}, ignore []string) []string {
+	d := &differ{
+		ignore:  make(map[string]bool, len(ignore)),
+		visited: make(map[[2]uintptr]bool),
+	}
+
+	for _, field := range ignore {
+		d.ignore[field] = true
+	}
+
+	d.walk("", reflect.ValueOf(want), reflect.ValueOf(got))
+	return d.lines
+}
+
+type differ struct {
+	ignore  map[string]bool
+	visited map[[2]uintptr]bool
+	lines   []string
+}
+
+func (d *differ) report(path string, want, got string) {
+	if path == "" {
+		path = "value"
+	}
+
+	d.lines = append(d.lines, fmt.Sprintf("%s: want %s, got %s", path, want, got))
+}
+
+func (d *differ) walk(path string, w, g reflect.Value) {
+	if !w.IsValid() || !g.IsValid() {
+		if w.IsValid() != g.IsValid() {
+			d.report(path, formatValue(w), formatValue(g))
+		}
+
+		return
+	}
+
+	if w.Type() != g.Type() {
+		d.report(path, fmt.Sprintf("%s (%s)", formatValue(w), w.Type()), fmt.Sprintf("%s (%s)", formatValue(g), g.Type()))
+		return
+	}
+
+	if w.Type() == timeType && w.CanInterface() {
+		if !w.Interface().(time.Time).Equal(g.Interface().(time.Time)) {
+			d.report(path, formatValue(w), formatValue(g))
+		}
+
+		return
+	}
+
+	switch w.Kind() {
+	case reflect.Ptr:
+		if w.IsNil() || g.IsNil() {
+			if w.IsNil() != g.IsNil() {
+				d.report(path, formatValue(w), formatValue(g))
+			}
+
+			return
+		}
+
+		// Avoids walking cyclic structures forever.
+		key := [2]uintptr{w.Pointer(), g.Pointer()}
+		if d.visited[key] {
+			return
+		}
+
+		d.visited[key] = true
+		d.walk(path, w.Elem(), g.Elem())
+
+	case reflect.Interface:
+		if w.IsNil() || g.IsNil() {
+			if w.IsNil() != g.IsNil() {
+				d.report(path, formatValue(w), formatValue(g))
+			}
+
+			return
+		}
+
+		d.walk(path, w.Elem(), g.Elem())
+
+	case reflect.Struct:
+		d.walkStruct(path, w, g)
+
+	case reflect.Slice, reflect.Array:
+		if w.Kind() == reflect.Slice && w.IsNil() != g.IsNil() && (w.Len() > 0 || g.Len() > 0) {
+			d.report(path, formatValue(w), formatValue(g))
+			return
+		}
+
+		for i := 0; i < w.Len() || i < g.Len(); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= g.Len():
+				d.report(p, formatValue(w.Index(i)), "nothing")
+			case i >= w.Len():
+				d.report(p, "nothing", formatValue(g.Index(i)))
+			default:
+				d.walk(p, w.Index(i), g.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		d.walkMap(path, w, g)
+
+	default:
+		if formatValue(w) != formatValue(g) {
+			d.report(path, formatValue(w), formatValue(g))
+		}
+	}
+}
+
+func (d *differ) walkStruct(path string, w, g reflect.Value) {
+	// The unexported fields of protobuf messages hold their internal state,
+	// which is not part of the message contents.
+	isProto := reflect.PointerTo(w.Type()).Implements(protoMessageType)
+
+	for i := 0; i < w.NumField(); i++ {
+		field := w.Type().Field(i)
+		if isProto && !field.IsExported() {
+			continue
+		}
+
+		p := field.Name
+		if path != "" {
+			p = path + "." + field.Name
+		}
+
+		if d.ignored(field.Name, p) {
+			continue
+		}
+
+		d.walk(p, w.Field(i), g.Field(i))
+	}
+}
+
+func (d *differ) walkMap(path string, w, g reflect.Value) {
+	keys := make(map[string]reflect.Value)
+	for _, k := range append(w.MapKeys(), g.MapKeys()...) {
+		keys[formatValue(k)] = k
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		p := fmt.Sprintf("%s[%s]", path, name)
+		wv, gv := w.MapIndex(keys[name]), g.MapIndex(keys[name])
+
+		switch {
+		case !gv.IsValid():
+			d.report(p, formatValue(wv), "nothing")
+		case !wv.IsValid():
+			d.report(p, "nothing", formatValue(gv))
+		default:
+			d.walk(p, wv, gv)
+		}
+	}
+}
+
+// ignored tells if a field is ignored by name or by path, disregarding
+// slice indexes and map keys.
+func (d *differ) ignored(name, path string) bool {
+	if d.ignore[name] {
+		return true
+	}
+
+	var b strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+
+	return d.ignore[b.String()]
+}
+
+// formatValue formats a value without requiring it to be exported.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(v.Complex(), 'g', -1, 128)
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+
+	if v.CanInterface() {
+		if m, ok := v.Interface().(proto.Message); ok {
+			return fmt.Sprintf("{%v}", m)
+		}
+
+		return fmt.Sprintf("%+v", v.Interface())
+	}
+
+	return fmt.Sprintf("<%s>", v.Type())
+}
+
+// diffMatcher matches values without differences, ignoring some fields.
+type diffMatcher struct {
+	want   interface{}
+	ignore []string
+}
+
+func (m diffMatcher) Matches(x interface{}) bool {
+	return len(diffLines(m.want, x, m.ignore)) == 0
+}
+
+func (m diffMatcher) String() string {
+	return fmt.Sprintf("is equal to %v (%T) ignoring %s", m.want, m.want, strings.Join(m.ignore, ", "))
+}
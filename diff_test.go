@@ -0,0 +1,137 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+type diffMeta struct {
+	CreatedAt time.Time
+	Tags      map[string]string
+}
+
+type diffOrder struct {
+	Id    string
+	Items []*example.Example
+	Meta  diffMeta
+	notes string
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("should render nested differences", func(t *testing.T) {
+		a := assert.New(t)
+
+		want := &diffOrder{
+			Id:    "1",
+			Items: []*example.Example{{Id: "1", Value: "Hello World"}},
+			Meta:  diffMeta{Tags: map[string]string{"env": "test"}},
+		}
+		got := &diffOrder{
+			Id:    "1",
+			Items: []*example.Example{{Id: "1", Value: "Hello"}, {Id: "2"}},
+			Meta:  diffMeta{Tags: map[string]string{"env": "prod"}},
+		}
+
+		diff := Diff(want, got)
+
+		a.Contains(diff, `Items[0].Value: want "Hello World", got "Hello"`)
+		a.Contains(diff, `Items[1]: want nothing, got`)
+		a.Contains(diff, `Meta.Tags["env"]: want "test", got "prod"`)
+		a.NotContains(diff, "Id: want")
+	})
+
+	t.Run("should compare unexported fields", func(t *testing.T) {
+		a := assert.New(t)
+
+		diff := Diff(diffOrder{notes: "first"}, diffOrder{notes: "second"})
+
+		a.Equal(`notes: want "first", got "second"`, diff)
+	})
+
+	t.Run("should ignore fields by name and by path", func(t *testing.T) {
+		a := assert.New(t)
+
+		want := diffOrder{Id: "1", Meta: diffMeta{CreatedAt: time.Now()}}
+		got := diffOrder{Id: "2", Meta: diffMeta{CreatedAt: time.Now().Add(time.Hour)}}
+
+		a.Empty(Diff(want, got, "Id", "Meta.CreatedAt"))
+		a.Equal(`Id: want "1", got "2"`, Diff(want, got, "CreatedAt"))
+	})
+
+	t.Run("should compare protobuf messages by their contents", func(t *testing.T) {
+		a := assert.New(t)
+
+		want, err := structpb.NewStruct(map[string]interface{}{"name": "Hello World", "count": 1})
+		a.NoError(err)
+		got, err := structpb.NewStruct(map[string]interface{}{"name": "Hello", "count": 1})
+		a.NoError(err)
+
+		diff := Diff(want, got)
+
+		a.Contains(diff, `Fields["name"].Kind.StringValue: want "Hello World", got "Hello"`)
+		a.NotContains(diff, "count")
+		a.NotContains(diff, "state")
+	})
+
+	t.Run("should match input ignoring fields", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		expectedOutput := &example.Example{Id: "2", Value: "Mocked Output"}
+
+		mock.Mock(&MockOptions{
+			Ctx:          ctx,
+			Call:         mock.Recorder().WithStruct,
+			Times:        1,
+			Input:        []interface{}{&example.Example{Value: "Hello World"}},
+			IgnoreFields: []string{"Id"},
+			Return:       expectedOutput,
+		})
+
+		c := mock.Client()
+		output, err := c.WithStruct(ctx, &example.Example{Id: "generated", Value: "Hello World"})
+
+		a.NoError(err)
+		a.Equal(expectedOutput, output)
+	})
+
+	t.Run("should show the differences when the input does not match", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:   ctx,
+			Call:  mock.Recorder().WithStruct,
+			Times: 1,
+			Input: []interface{}{&example.Example{Id: "1", Value: "Hello World"}},
+		})
+
+		run(func() {
+			_, _ = mock.Client().WithStruct(ctx, &example.Example{Id: "1", Value: "Hello"})
+		})
+
+		failures := reporter.finish()
+
+		a.Contains(failures, "Unexpected call")
+		a.Contains(failures, "Diff:\n\tValue: want \"Hello World\", got \"Hello\"")
+	})
+}
@@ -0,0 +1,316 @@
+package mocks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Dynamic is a mock of the interface I built at test time from its method
+// set, without generating code with mockgen. It satisfies ServiceClient, so
+// it is used through New, Mock and Recorder like the generated mocks:
+//
+//	mock := mocks.New(t, mocks.NewDynamic[example.ExampleMock])
+//	mock.Mock(&mocks.MockOptions{
+//		Call:   mock.Recorder().Method("GetByString"),
+//		Times:  1,
+//		Return: "Mocked Output",
+//	})
+//
+//	getByString := mocks.DynamicFunc[func(context.Context, string) (string, error)](mock.Client(), "GetByString")
+//
+// Go can not declare methods at runtime, so a Dynamic does not implement I
+// itself. Its methods are called through Call, or through the functions
+// returned by Func, which can be assigned to the function fields of a
+// struct with Implement. As returns an I from such a struct, whose methods
+// call its fields.
+type Dynamic[I any] struct {
+	ctrl     *gomock.Controller
+	typ      reflect.Type
+	recorder *DynamicRecorder
+}
+
+// DynamicRecorder is the recorder of a Dynamic mock, whose recording
+// functions are looked up by method name.
+type DynamicRecorder struct {
+	mock interface{}
+	ctrl *gomock.Controller
+	typ  reflect.Type
+
+	mu      sync.Mutex
+	methods map[string]reflect.Value
+}
+
+// NewDynamic returns a new mock of the interface I. It panics if I is not
+// an interface.
+func NewDynamic[I any](ctrl *gomock.Controller) *Dynamic[I] {
+	typ := reflect.TypeOf((*I)(nil)).Elem()
+	if typ.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("NewDynamic requires an interface, got %v", typ))
+	}
+
+	d := &Dynamic[I]{
+		ctrl: ctrl,
+		typ:  typ,
+	}
+
+	d.recorder = &DynamicRecorder{
+		mock:    d,
+		ctrl:    ctrl,
+		typ:     typ,
+		methods: make(map[string]reflect.Value),
+	}
+
+	return d
+}
+
+// EXPECT returns the recorder of the mock.
+func (d *Dynamic[I]) EXPECT() *DynamicRecorder {
+	return d.recorder
+}
+
+// Call calls the method with args, the variadic ones included one by one,
+// returning its results, like the methods of the generated mocks do.
+func (d *Dynamic[I]) Call(method string, args ...interface{}) []interface{} {
+	d.ctrl.T.Helper()
+	d.method(method)
+
+	return d.ctrl.Call(d, method, args...)
+}
+
+// Func returns a function of the type of the method that calls it.
+func (d *Dynamic[I]) Func(method string) interface{} {
+	mt := d.method(method).Type
+
+	return reflect.MakeFunc(mt, func(in []reflect.Value) []reflect.Value {
+		d.ctrl.T.Helper()
+
+		return returnValuesOf(mt, d.ctrl.Call(d, method, flattenArgs(mt, in)...))
+	}).Interface()
+}
+
+// Implement sets the nil function fields of the struct pointed to by
+// target to the functions of the methods with the same names and types,
+// returning how many were set. The names of the fields may also end with
+// Func, so that the struct can have the methods themselves.
+func (d *Dynamic[I]) Implement(target interface{}) int {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("Implement requires a pointer to a struct, got %T", target))
+	}
+
+	set := 0
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Func || !v.Field(i).IsNil() {
+			continue
+		}
+
+		name := field.Name
+		if _, ok := d.typ.MethodByName(name); !ok {
+			name = strings.TrimSuffix(name, "Func")
+		}
+
+		if m, ok := d.typ.MethodByName(name); ok && m.Type == field.Type {
+			v.Field(i).Set(reflect.ValueOf(d.Func(name)))
+			set++
+		}
+	}
+
+	return set
+}
+
+// As sets the function fields of the struct pointed to by target with
+// Implement, and returns target as an I. The struct implements I with
+// methods calling its fields, which are written once per interface:
+//
+//	type exampleFuncs struct {
+//		GetByStringFunc func(context.Context, string) (string, error)
+//	}
+//
+//	func (f *exampleFuncs) GetByString(ctx context.Context, in string) (string, error) {
+//		return f.GetByStringFunc(ctx, in)
+//	}
+//
+//	var client example.ExampleMock = mock.Client().As(&exampleFuncs{})
+//
+// It panics if target does not implement I.
+func (d *Dynamic[I]) As(target interface{}) I {
+	client, ok := target.(I)
+	if !ok {
+		panic(fmt.Sprintf("%T does not implement %v", target, d.typ))
+	}
+
+	d.Implement(target)
+	return client
+}
+
+// methodType implements methodTyper, as the methods of a Dynamic mock are
+// not methods of its type.
+func (d *Dynamic[I]) methodType(name string) (reflect.Type, bool) {
+	m, ok := d.typ.MethodByName(name)
+	return m.Type, ok
+}
+
+// recordedMethod implements methodRecorder. The recording functions are
+// made by reflect.MakeFunc, which gives them all the same code pointer, so
+// they are told apart by their closures.
+func (d *Dynamic[I]) recordedMethod(call reflect.Value) string {
+	r := d.recorder
+	id := funcID(call)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, fn := range r.methods {
+		if funcID(fn) == id {
+			return name
+		}
+	}
+
+	panic("Call must be a function of the recorder of the dynamic mock, example: mock.Recorder().Method(\"GetByString\")")
+}
+
+// methodNames implements methodLister.
+func (d *Dynamic[I]) methodNames() []string {
+	names := make([]string, d.typ.NumMethod())
+	for i := range names {
+		names[i] = d.typ.Method(i).Name
+	}
+
+	return names
+}
+
+// interfaceName implements interfaceNamer, as every Dynamic mocks the
+// interface of its type argument.
+func (d *Dynamic[I]) interfaceName() string {
+	return fmt.Sprintf("*mocks.Dynamic[%v]", d.typ)
+}
+
+func (d *Dynamic[I]) method(name string) reflect.Method {
+	m, ok := d.typ.MethodByName(name)
+	if !ok {
+		panic(fmt.Sprintf("method %s not found in %v", name, d.typ))
+	}
+
+	return m
+}
+
+// Method returns the function recording the calls to the method, to be
+// used as MockOptions.Call. Like the methods of the generated recorders, it
+// receives the matchers, or values, of each argument.
+func (r *DynamicRecorder) Method(name string) interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if fn, ok := r.methods[name]; ok {
+		return fn.Interface()
+	}
+
+	m, ok := r.typ.MethodByName(name)
+	if !ok {
+		panic(fmt.Sprintf("method %s not found in %v", name, r.typ))
+	}
+
+	in := make([]reflect.Type, m.Type.NumIn())
+	for i := range in {
+		in[i] = anyType
+	}
+
+	if m.Type.IsVariadic() {
+		in[len(in)-1] = reflect.TypeOf([]interface{}{})
+	}
+
+	ft := reflect.FuncOf(in, []reflect.Type{reflect.TypeOf((*gomock.Call)(nil))}, m.Type.IsVariadic())
+	fn := reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		r.ctrl.T.Helper()
+
+		call := r.ctrl.RecordCallWithMethodType(r.mock, name, m.Type, flattenArgs(ft, args)...)
+		return []reflect.Value{reflect.ValueOf(call)}
+	})
+
+	r.methods[name] = fn
+	return fn.Interface()
+}
+
+// DynamicFunc returns the function of the method of a Dynamic mock, with
+// its type.
+func DynamicFunc[F any, I any](d *Dynamic[I], method string) F {
+	fn, ok := d.Func(method).(F)
+	if !ok {
+		var zero F
+		panic(fmt.Sprintf("method %s of %v is not a %T", method, d.typ, zero))
+	}
+
+	return fn
+}
+
+// methodTyper is implemented by the mock clients whose methods are not
+// methods of their types.
+type methodTyper interface {
+	methodType(name string) (reflect.Type, bool)
+}
+
+// methodRecorder is implemented by the mock clients whose recording
+// functions are not method values of their recorders.
+type methodRecorder interface {
+	recordedMethod(call reflect.Value) string
+}
+
+// recordedMethod returns the name of the method recorded by call, which the
+// client resolves itself if it is a methodRecorder.
+func (tr *tracker) recordedMethod(call reflect.Value) string {
+	if r, ok := tr.client.(methodRecorder); ok {
+		return r.recordedMethod(call)
+	}
+
+	return recordedMethod(call)
+}
+
+// methodType returns the type of a method of the client, which resolves
+// it itself if it is a methodTyper.
+func (tr *tracker) methodType(name string) (reflect.Type, bool) {
+	if c, ok := tr.client.(methodTyper); ok {
+		return c.methodType(name)
+	}
+
+	method := reflect.ValueOf(tr.client).MethodByName(name)
+	if !method.IsValid() {
+		return nil, false
+	}
+
+	return method.Type(), true
+}
+
+var anyType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// funcID identifies a function by its closure, the data word of the
+// interface holding it.
+func funcID(fn reflect.Value) unsafe.Pointer {
+	i := fn.Interface()
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&i))[1]
+}
+
+// flattenArgs returns the arguments of a call to a function of type ft,
+// passing the variadic ones one by one.
+func flattenArgs(ft reflect.Type, in []reflect.Value) []interface{} {
+	args := make([]interface{}, 0, len(in))
+	for i, arg := range in {
+		if ft.IsVariadic() && i == len(in)-1 {
+			for j := 0; j < arg.Len(); j++ {
+				args = append(args, arg.Index(j).Interface())
+			}
+
+			continue
+		}
+
+		args = append(args, arg.Interface())
+	}
+
+	return args
+}
@@ -0,0 +1,213 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/somatech1/mocks/internal/example"
+)
+
+func TestDynamic(t *testing.T) {
+	t.Run("should mock an interface without generated code", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[example.ExampleMock],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().Method("GetByString"),
+			Input:  "Mocked Input",
+			Times:  1,
+			Return: "Mocked Output",
+		}).Mock(&MockOptions{
+			Ctx:   ctx,
+			Call:  mock.Recorder().Method("WithStruct"),
+			Times: 1,
+			Error: errors.New("mocks: service is down"),
+		})
+
+		getByString := DynamicFunc[func(context.Context, string) (string, error)](mock.Client(), "GetByString")
+		out, err := getByString(ctx, "Mocked Input")
+		a.NoError(err)
+		a.Equal("Mocked Output", out)
+
+		rets := mock.Client().Call("WithStruct", ctx, &example.Example{Id: "1"})
+		a.Nil(rets[0])
+		a.EqualError(rets[1].(error), "mocks: service is down")
+
+		a.Equal(1, mock.Calls("GetByString"))
+		a.Equal(1, mock.Calls("WithStruct"))
+	})
+
+	t.Run("should pass variadic arguments one by one", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[example.ExampleMock],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().Method("GetWithVariadic"),
+			Input:  []interface{}{"id", "a", "b"},
+			Times:  1,
+			Return: 2,
+		})
+
+		fn := DynamicFunc[func(context.Context, string, ...string) (int, error)](mock.Client(), "GetWithVariadic")
+		out, err := fn(ctx, "id", "a", "b")
+		a.NoError(err)
+		a.Equal(2, out)
+	})
+
+	t.Run("should implement the function fields of a struct", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[example.ExampleMock],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().Method("GetByInt"),
+			AnyTimes: true,
+			Return:   42,
+		})
+
+		var deps struct {
+			GetByInt    func(context.Context, int) (int, error)
+			GetByString func(context.Context, int) (string, error)
+			Other       func()
+		}
+
+		a.Equal(1, mock.Client().Implement(&deps))
+		a.Nil(deps.GetByString)
+
+		out, err := deps.GetByInt(ctx, 1)
+		a.NoError(err)
+		a.Equal(42, out)
+	})
+
+	t.Run("should return the interface implemented by a struct of functions", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[pinger],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().Method("Ping"),
+			Input:  "Hello",
+			Times:  1,
+			Return: "World",
+		})
+
+		var client pinger = mock.Client().As(&pingerFuncs{})
+
+		out, err := client.Ping(ctx, "Hello")
+		a.NoError(err)
+		a.Equal("World", out)
+
+		a.PanicsWithValue("*struct {} does not implement mocks.pinger", func() {
+			mock.Client().As(&struct{}{})
+		})
+	})
+
+	t.Run("should resolve the methods recorded by their functions", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[batcher],
+		)
+
+		// The slice is the only argument of the method, which is only known
+		// once the recorded method is.
+		mock.Mock(&MockOptions{
+			Ctx:                 ctx,
+			Call:                mock.Recorder().Method("Send"),
+			Input:               []string{"a", "b"},
+			Times:               1,
+			SingleErrorReturned: true,
+		})
+
+		send := DynamicFunc[func(context.Context, []string) error](mock.Client(), "Send")
+		a.NoError(send(ctx, []string{"a", "b"}))
+
+		a.Equal("Send", mock.tracker.recordedMethod(reflect.ValueOf(mock.Recorder().Method("Send"))))
+		a.PanicsWithValue(`Call must be a function of the recorder of the dynamic mock, example: mock.Recorder().Method("GetByString")`, func() {
+			mock.tracker.recordedMethod(reflect.ValueOf(func() {}))
+		})
+	})
+
+	t.Run("should report unmet expectations of dynamic mocks", func(t *testing.T) {
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			NewDynamic[example.ExampleMock],
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().Method("GetByString"),
+			Times:  1,
+			Return: "Mocked Output",
+			Label:  "dynamic",
+		})
+
+		failures := reporter.finish()
+		a.Contains(failures, `GetByString "dynamic" set up at dynamic_test.go:`)
+	})
+
+	t.Run("should panic for unknown methods and types", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[example.ExampleMock],
+		)
+
+		a.PanicsWithValue("method Unknown not found in example.ExampleMock", func() {
+			mock.Recorder().Method("Unknown")
+		})
+
+		a.PanicsWithValue("NewDynamic requires an interface, got example.Example", func() {
+			NewDynamic[example.Example](gomock.NewController(t))
+		})
+	})
+}
+
+type pinger interface {
+	Ping(ctx context.Context, in string) (string, error)
+}
+
+// pingerFuncs implements pinger with function fields, set by Dynamic.As.
+type pingerFuncs struct {
+	PingFunc func(context.Context, string) (string, error)
+}
+
+func (p *pingerFuncs) Ping(ctx context.Context, in string) (string, error) {
+	return p.PingFunc(ctx, in)
+}
+
+type batcher interface {
+	Send(ctx context.Context, ids []string) error
+}
@@ -0,0 +1,532 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Expectation is a single call expected through Mock. It can be used to
+// follow the calls that match it, which is useful when they are made by
+// other goroutines.
+type Expectation struct {
+	tracker    *tracker
+	label      string
+	site       string
+	method     string
+	methodType reflect.Type
+	minCalls   int
+	ignore     []string
+	group      *Group
+	inStates   []string
+	nextState  string
+
+	// inputs are the matchers of the arguments, and returns the results set
+	// up with Return, from which the expected call is replayed by
+	// VerifyContract when it was never made.
+	inputs  []*trackedMatcher
+	returns []interface{}
+
+	// isReady is set once the expectation is fully set up. Until then its
+	// matchers fail, so that concurrent calls do not see the gomock call
+	// while it is being configured.
+	isReady atomic.Bool
+
+	// cleared is set when the group of the expectation is cleared.
+	cleared atomic.Bool
+
+	mu      sync.Mutex
+	calls   int
+	args    [][]interface{}
+	closest *mismatch
+}
+
+// mismatch is an argument of a call that did not match the expectation.
+type mismatch struct {
+	index int
+	want  *trackedMatcher
+	got   interface{}
+}
+
+func (tr *tracker) newExpectation(opts *MockOptions) *Expectation {
+	minCalls := opts.Times
+	if opts.AnyTimes {
+		minCalls = 0
+	}
+
+	return &Expectation{
+		tracker:   tr,
+		label:     opts.Label,
+		site:      callerSite(),
+		minCalls:  minCalls,
+		ignore:    opts.IgnoreFields,
+		group:     opts.Group,
+		inStates:  opts.InStates,
+		nextState: opts.NextState,
+	}
+}
+
+// bind associates the expectation with the gomock call created for it,
+// from which the mocked method is found. Only bound expectations are
+// tracked.
+func (e *Expectation) bind(call *gomock.Call) {
+	// The call description starts with the receiver and method name, i.e,
+	// "*mock_example.MockExampleMock.GetByString(...) origin".
+	prefix := fmt.Sprintf("%T.", e.tracker.client)
+	desc := call.String()
+	if !strings.HasPrefix(desc, prefix) {
+		return
+	}
+
+	name := strings.TrimPrefix(desc, prefix)
+	name = name[:strings.Index(name, "(")]
+
+	mt, ok := e.tracker.methodType(name)
+	if !ok {
+		return
+	}
+
+	e.method = name
+	e.methodType = mt
+	e.tracker.coverage.mock(e.tracker.client, name)
+
+	e.tracker.mu.Lock()
+	defer e.tracker.mu.Unlock()
+
+	e.tracker.expectations = append(e.tracker.expectations, e)
+}
+
+func (e *Expectation) ready() {
+	e.isReady.Store(true)
+}
+
+// active tells if the expectation can match calls, which it can not do
+// before being fully set up, nor when its group is disabled or cleared, nor
+// outside of its states.
+func (e *Expectation) active() bool {
+	return e.isReady.Load() && e.inactive() == ""
+}
+
+// inactive describes why a set up expectation can not match calls, or
+// returns an empty string if it can.
+func (e *Expectation) inactive() string {
+	switch {
+	case e.cleared.Load():
+		return "its group was cleared"
+	case e.group != nil && !e.group.Enabled():
+		return fmt.Sprintf("group %q is disabled", e.group.Name())
+	case e.tracker.states.Load() != nil:
+		return e.tracker.states.Load().inactive(e)
+	}
+
+	return ""
+}
+
+// required tells if the expectation has to be met.
+func (e *Expectation) required() bool {
+	return !e.cleared.Load() && (e.group == nil || e.group.Enabled())
+}
+
+func (e *Expectation) tracked() bool {
+	return e.methodType != nil
+}
+
+// track wraps an input of the expectation into a matcher that records the
+// arguments it fails to match.
+func (e *Expectation) track(index int, input reflect.Value) reflect.Value {
+	m := &trackedMatcher{
+		expectation: e,
+		index:       index,
+	}
+
+	var value interface{}
+	if input.IsValid() {
+		value = input.Interface()
+	}
+
+	switch v := value.(type) {
+	case protoMatcher:
+		m.Matcher = v
+		m.value = v.message
+		m.hasValue = true
+	case gomock.Matcher:
+		m.Matcher = v
+	case nil:
+		m.Matcher = gomock.Nil()
+	default:
+		m.Matcher = gomock.Eq(v)
+		m.value = v
+		m.hasValue = true
+	}
+
+	if m.hasValue && len(e.ignore) > 0 {
+		m.Matcher = diffMatcher{want: m.value, ignore: e.ignore}
+	}
+
+	e.inputs = append(e.inputs, m)
+	return reflect.ValueOf(m)
+}
+
+// expected returns the call described by a required expectation that was
+// never matched, with its inputs and the results set up with Return. It
+// returns false if the expectation was called, or if any of its inputs,
+// other than contexts, matches more than one value.
+func (e *Expectation) expected() (*invocation, bool) {
+	e.mu.Lock()
+	calls := e.calls
+	e.mu.Unlock()
+
+	if !e.tracked() || calls > 0 || e.returns == nil || !e.required() {
+		return nil, false
+	}
+
+	mt := e.methodType
+	fixed := mt.NumIn()
+	if mt.IsVariadic() {
+		fixed--
+	}
+
+	if len(e.inputs) < fixed {
+		return nil, false
+	}
+
+	args := make([]interface{}, 0, mt.NumIn())
+	for i, in := range e.inputs[:fixed] {
+		switch {
+		case mt.In(i) == contextType:
+			args = append(args, context.Background())
+		case in.hasValue && reflect.TypeOf(in.value).AssignableTo(mt.In(i)):
+			args = append(args, in.value)
+		default:
+			return nil, false
+		}
+	}
+
+	if !mt.IsVariadic() {
+		return &invocation{method: e.method, methodType: mt, args: args, site: e.site, mocked: e.returns}, true
+	}
+
+	// Variadic arguments are matched one by one, and received as a slice.
+	variadic := mt.In(fixed)
+	tail := reflect.MakeSlice(variadic, 0, len(e.inputs)-fixed)
+	for _, in := range e.inputs[fixed:] {
+		if !in.hasValue || !reflect.TypeOf(in.value).AssignableTo(variadic.Elem()) {
+			return nil, false
+		}
+
+		tail = reflect.Append(tail, reflect.ValueOf(in.value))
+	}
+
+	args = append(args, tail.Interface())
+	return &invocation{method: e.method, methodType: mt, args: args, site: e.site, mocked: e.returns}, true
+}
+
+// respond registers the action run when the expectation is matched, which
+// counts the call and returns the values given by results. Calls are logged
+// once they return or panic.
+func (e *Expectation) respond(call *gomock.Call, results func(args []reflect.Value) []interface{}) {
+	mt := e.methodType
+	fn := reflect.MakeFunc(mt, func(args []reflect.Value) []reflect.Value {
+		inv := e.called(args)
+		returned := false
+
+		defer func() {
+			if !returned {
+				// A nil value means that the call is exiting its goroutine,
+				// which is not logged.
+				r := recover()
+				if r == nil {
+					return
+				}
+
+				inv.panicked = true
+				inv.panicValue = r
+				e.tracker.logCall(inv)
+				panic(r)
+			}
+
+			e.tracker.logCall(inv)
+		}()
+
+		inv.rets = append([]interface{}{}, results(args)...)
+		inv.mocked = append([]interface{}{}, inv.rets...)
+		e.tracker.perturb(inv)
+
+		// Failed calls do not change the state of the service.
+		if e.nextState != "" && !inv.failed() {
+			e.tracker.states.Load().transition(e)
+		}
+
+		values := returnValuesOf(mt, inv.rets)
+		returned = true
+
+		return values
+	})
+
+	call.DoAndReturn(fn.Interface())
+}
+
+func (e *Expectation) called(args []reflect.Value) *invocation {
+	captured := make([]interface{}, len(args))
+	for i, arg := range args {
+		captured[i] = arg.Interface()
+	}
+
+	e.mu.Lock()
+	e.calls++
+	e.args = append(e.args, captured)
+	e.mu.Unlock()
+
+	e.tracker.mu.Lock()
+	defer e.tracker.mu.Unlock()
+
+	e.tracker.total++
+	e.tracker.calls[e.method]++
+	e.tracker.coverage.call(e.tracker.client, e.method)
+	close(e.tracker.changed)
+	e.tracker.changed = make(chan struct{})
+
+	return &invocation{
+		index:      e.tracker.total,
+		method:     e.method,
+		methodType: e.methodType,
+		args:       captured,
+		site:       e.site,
+	}
+}
+
+// mismatched records an argument that did not match. The closest call is
+// the one that matched more arguments before failing.
+func (e *Expectation) mismatched(index int, want *trackedMatcher, got interface{}) {
+	if e.methodType != nil && e.methodType.IsVariadic() && index >= e.methodType.NumIn()-1 {
+		// gomock tries to match variadic arguments one by one before trying
+		// them as a whole, so only the last attempt is taken into account.
+		if reflect.TypeOf(got) != e.methodType.In(e.methodType.NumIn()-1) {
+			return
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closest == nil || index >= e.closest.index {
+		e.closest = &mismatch{index: index, want: want, got: got}
+	}
+}
+
+// Calls returns how many times the expectation was matched.
+func (e *Expectation) Calls() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.calls
+}
+
+// Args returns the arguments received by each call that matched the
+// expectation, including the context. Variadic arguments are given as a
+// slice in the last position.
+func (e *Expectation) Args() [][]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([][]interface{}{}, e.args...)
+}
+
+// Satisfied tells if the expectation was matched the number of times set
+// by MockOptions.Times, or at least once when it was set with AnyTimes.
+func (e *Expectation) Satisfied() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.satisfied()
+}
+
+func (e *Expectation) satisfied() bool {
+	return e.calls > 0 && e.calls >= e.minCalls
+}
+
+// Wait blocks until the expectation is satisfied, returning the arguments
+// of the last call that matched it, or an error if the timeout elapses
+// before that.
+func (e *Expectation) Wait(timeout time.Duration) ([]interface{}, error) {
+	if !e.tracked() {
+		return nil, fmt.Errorf("mocks: expectation set up at %s can not be waited for", e.site)
+	}
+
+	satisfied := e.tracker.wait(timeout, func() bool {
+		return e.Satisfied()
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !satisfied {
+		return nil, fmt.Errorf(
+			"mocks: timed out after %v waiting for %s set up at %s, got %d of %d call(s)",
+			timeout, e.name(), e.site, e.calls, e.minCalls,
+		)
+	}
+
+	return e.args[len(e.args)-1], nil
+}
+
+func (e *Expectation) unmet() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.required() && e.calls < e.minCalls
+}
+
+func (e *Expectation) name() string {
+	if e.label != "" {
+		return fmt.Sprintf("%s %q", e.method, e.label)
+	}
+
+	return e.method
+}
+
+// trackedMatcher wraps the matcher of an expectation input, recording the
+// arguments that it does not match.
+type trackedMatcher struct {
+	gomock.Matcher
+	expectation *Expectation
+	index       int
+
+	// value is the expected value when the matcher was created from one.
+	value    interface{}
+	hasValue bool
+}
+
+func (m *trackedMatcher) Matches(x interface{}) bool {
+	if !m.expectation.active() {
+		return false
+	}
+
+	if m.Matcher.Matches(x) {
+		return true
+	}
+
+	m.expectation.mismatched(m.index, m, x)
+	return false
+}
+
+// String describes the matcher, along with the reason why its expectation
+// can not match calls, if any, so that unexpected calls can be understood.
+func (m *trackedMatcher) String() string {
+	s := m.Matcher.String()
+	if m.index > 0 || !m.expectation.isReady.Load() {
+		return s
+	}
+
+	if reason := m.expectation.inactive(); reason != "" {
+		s += " (" + reason + ")"
+	}
+
+	return s
+}
+
+// Got implements gomock.GotFormatter, adding the differences between the
+// expected value and x, so that failures show which fields changed.
+func (m *trackedMatcher) Got(x interface{}) string {
+	got := fmt.Sprintf("%v (%T)", x, x)
+	if g, ok := m.Matcher.(gomock.GotFormatter); ok {
+		got = g.Got(x)
+	}
+
+	if !m.hasValue {
+		return got
+	}
+
+	lines := diffLines(m.value, x, m.expectation.ignore)
+	if len(lines) == 0 {
+		return got
+	}
+
+	return got + "\nDiff:\n\t" + strings.Join(lines, "\n\t")
+}
+
+// invocation is a call matched by an expectation, whose results can still
+// be changed by the layers set on the mock client, like fault injection.
+type invocation struct {
+	// index is the position of the call among all calls made to the mock
+	// client, starting at 1.
+	index      int
+	method     string
+	methodType reflect.Type
+	args       []interface{}
+	rets       []interface{}
+
+	// site is where the expectation matched by the call was set up.
+	site string
+
+	// mocked holds the results set up by the expectation, before any
+	// layer changed them.
+	mocked []interface{}
+
+	// panicked is set when the call panicked with panicValue.
+	panicked   bool
+	panicValue interface{}
+}
+
+// context returns the context received by the call, if any.
+func (inv *invocation) context() context.Context {
+	if len(inv.args) > 0 {
+		if ctx, ok := inv.args[0].(context.Context); ok {
+			return ctx
+		}
+	}
+
+	return context.Background()
+}
+
+// canFail tells if the last result of the method is an error.
+func (inv *invocation) canFail() bool {
+	mt := inv.methodType
+	return mt.NumOut() > 0 && mt.Out(mt.NumOut()-1) == errorType
+}
+
+// failed tells if the call is returning an error.
+func (inv *invocation) failed() bool {
+	if !inv.canFail() {
+		return false
+	}
+
+	err, _ := inv.rets[len(inv.rets)-1].(error)
+	return err != nil
+}
+
+// fail makes the call return err along with zero values.
+func (inv *invocation) fail(err error) {
+	for i := range inv.rets {
+		inv.rets[i] = nil
+	}
+
+	inv.rets[len(inv.rets)-1] = err
+}
+
+// returnValuesOf converts the values returned for a call into the result
+// types of the mocked method.
+func returnValuesOf(mt reflect.Type, rets []interface{}) []reflect.Value {
+	values := make([]reflect.Value, mt.NumOut())
+	for i := range values {
+		out := mt.Out(i)
+		if i >= len(rets) || rets[i] == nil {
+			values[i] = reflect.Zero(out)
+			continue
+		}
+
+		v := reflect.New(out).Elem()
+		v.Set(reflect.ValueOf(rets[i]))
+		values[i] = v
+	}
+
+	return values
+}
@@ -0,0 +1,103 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestExpectation(t *testing.T) {
+	t.Run("should wait for the expectation and return the captured arguments", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		expectedOutput := &example.Example{Id: "2", Value: "Mocked Output"}
+
+		exp := mock.Expect(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().WithStruct,
+			Times:  1,
+			Return: expectedOutput,
+		})
+
+		input := &example.Example{Id: "1", Value: "Hello World"}
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_, _ = mock.Client().WithStruct(ctx, input)
+		}()
+
+		args, err := exp.Wait(time.Second)
+
+		a.NoError(err)
+		a.Equal([]interface{}{ctx, input}, args)
+		a.True(exp.Satisfied())
+	})
+
+	t.Run("should time out waiting for an unsatisfied expectation", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		exp := mock.Expect(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  2,
+			Input:  "Hello World",
+			Return: "Mocked Output",
+			Label:  "greeting",
+		})
+
+		_, err := mock.Client().GetByString(ctx, "Hello World")
+		a.NoError(err)
+
+		args, err := exp.Wait(10 * time.Millisecond)
+
+		a.Nil(args)
+		a.Error(err)
+		a.Contains(err.Error(), `GetByString "greeting"`)
+		a.Contains(err.Error(), "got 1 of 2 call(s)")
+		a.False(exp.Satisfied())
+
+		// satisfies the expectation so the test does not fail
+		_, err = mock.Client().GetByString(ctx, "Hello World")
+		a.NoError(err)
+	})
+
+	t.Run("should capture the arguments of every call", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		exp := mock.Expect(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			AnyTimes: true,
+			Input:    []interface{}{"Hello World", "Another One"},
+			Return:   1,
+		})
+
+		_, err := mock.Client().GetWithVariadic(ctx, "Hello World", "Another One")
+		a.NoError(err)
+
+		a.Equal(1, exp.Calls())
+		a.Equal([][]interface{}{{ctx, "Hello World", []string{"Another One"}}}, exp.Args())
+	})
+}
@@ -0,0 +1,390 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrNotFound is returned by the methods of a Fake for missing items.
+	// It is a gRPC status error with the NotFound code.
+	ErrNotFound = status.Error(codes.NotFound, "mocks: item not found")
+
+	// ErrAlreadyExists is returned by the creation methods of a Fake for
+	// items whose key is already stored. It is a gRPC status error with the
+	// AlreadyExists code.
+	ErrAlreadyExists = status.Error(codes.AlreadyExists, "mocks: item already exists")
+)
+
+// Fake backs the CRUD shaped methods of a mock client with an in-memory
+// store of items of type V, indexed by the keys of type K given by a key
+// extractor, so that sequential calls behave consistently. Items are copied
+// in and out of the store.
+//
+// Methods receive the key, or an item, as their first argument after the
+// context, and may return an item, a slice of items, an error, or any
+// combination of them.
+//
+// Example:
+//
+//	mocks.NewFake(mock, func(e *example.Example) string { return e.Id }).
+//		Get("GetExample").
+//		Create("CreateExample").
+//		Delete("DeleteExample")
+type Fake[K comparable, V any] struct {
+	key      func(V) K
+	expect   func(*MockOptions) *Expectation
+	client   reflect.Value
+	recorder reflect.Value
+
+	mu    sync.Mutex
+	items map[K]V
+	keys  []K
+}
+
+// NewFake returns a new fake with an empty store, backing methods of the
+// mock client once they are wired to it.
+func NewFake[R any, T ServiceClient[R], K comparable, V any](m *MockServiceClient[R, T], key func(V) K) *Fake[K, V] {
+	return &Fake[K, V]{
+		key:      key,
+		expect:   m.Expect,
+		client:   reflect.ValueOf(m.Client()),
+		recorder: reflect.ValueOf(m.Recorder()),
+		items:    make(map[K]V),
+	}
+}
+
+// Put stores items, replacing the ones with the same keys.
+func (f *Fake[K, V]) Put(items ...V) *Fake[K, V] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, item := range items {
+		f.store(item)
+	}
+
+	return f
+}
+
+// Items returns copies of the stored items, in the order they were first
+// stored.
+func (f *Fake[K, V]) Items() []V {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.list()
+}
+
+// Get makes the method return the item with the key it receives, or
+// ErrNotFound.
+func (f *Fake[K, V]) Get(method string) *Fake[K, V] {
+	return f.wire(method, func(args []reflect.Value) (interface{}, error) {
+		k, ok := f.keyOf(args)
+		if !ok {
+			return nil, f.missing(method, "key")
+		}
+
+		item, ok := f.items[k]
+		if !ok {
+			return nil, ErrNotFound
+		}
+
+		return cloneItem(item), nil
+	})
+}
+
+// List makes the method return all stored items.
+func (f *Fake[K, V]) List(method string) *Fake[K, V] {
+	return f.wire(method, func([]reflect.Value) (interface{}, error) {
+		return f.list(), nil
+	})
+}
+
+// Create makes the method store the item it receives, returning it, or
+// ErrAlreadyExists when its key is already stored.
+func (f *Fake[K, V]) Create(method string) *Fake[K, V] {
+	return f.wire(method, func(args []reflect.Value) (interface{}, error) {
+		item, ok := f.itemOf(args)
+		if !ok {
+			return nil, f.missing(method, "item")
+		}
+
+		if _, ok := f.items[f.key(item)]; ok {
+			return nil, ErrAlreadyExists
+		}
+
+		return cloneItem(f.store(item)), nil
+	})
+}
+
+// Update makes the method replace the stored item with the one it
+// receives, returning it, or ErrNotFound when its key is not stored.
+func (f *Fake[K, V]) Update(method string) *Fake[K, V] {
+	return f.wire(method, func(args []reflect.Value) (interface{}, error) {
+		item, ok := f.itemOf(args)
+		if !ok {
+			return nil, f.missing(method, "item")
+		}
+
+		if _, ok := f.items[f.key(item)]; !ok {
+			return nil, ErrNotFound
+		}
+
+		return cloneItem(f.store(item)), nil
+	})
+}
+
+// Delete makes the method remove the item with the key it receives,
+// returning it, or ErrNotFound.
+func (f *Fake[K, V]) Delete(method string) *Fake[K, V] {
+	return f.wire(method, func(args []reflect.Value) (interface{}, error) {
+		k, ok := f.keyOf(args)
+		if !ok {
+			return nil, f.missing(method, "key")
+		}
+
+		item, ok := f.items[k]
+		if !ok {
+			return nil, ErrNotFound
+		}
+
+		delete(f.items, k)
+		for i := range f.keys {
+			if f.keys[i] == k {
+				f.keys = append(f.keys[:i], f.keys[i+1:]...)
+				break
+			}
+		}
+
+		return cloneItem(item), nil
+	})
+}
+
+// fakePatterns are the prefixes of the method names wired by Auto.
+var fakePatterns = []struct {
+	prefixes []string
+	wire     string
+}{
+	{[]string{"Get", "Find", "Fetch", "Read"}, "Get"},
+	{[]string{"List", "All"}, "List"},
+	{[]string{"Create", "Insert", "Add"}, "Create"},
+	{[]string{"Update", "Save"}, "Update"},
+	{[]string{"Delete", "Remove"}, "Delete"},
+}
+
+// Auto wires the methods of the mock client by their names: the ones
+// starting with Get, Find, Fetch or Read are wired with Get, with List or
+// All with List, with Create, Insert or Add with Create, with Update or Save
+// with Update, and with Delete or Remove with Delete. Methods whose
+// arguments or results do not fit are left alone.
+func (f *Fake[K, V]) Auto() *Fake[K, V] {
+	typ := f.client.Type()
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+
+		for _, p := range fakePatterns {
+			if !hasAnyPrefix(name, p.prefixes) || !f.fits(name, p.wire) {
+				continue
+			}
+
+			switch p.wire {
+			case "Get":
+				f.Get(name)
+			case "List":
+				f.List(name)
+			case "Create":
+				f.Create(name)
+			case "Update":
+				f.Update(name)
+			case "Delete":
+				f.Delete(name)
+			}
+
+			break
+		}
+	}
+
+	return f
+}
+
+// wire sets up an expectation for the method, matching any call, that
+// runs op with the fake locked.
+func (f *Fake[K, V]) wire(method string, op func(args []reflect.Value) (interface{}, error)) *Fake[K, V] {
+	m := f.client.MethodByName(method)
+	if !m.IsValid() {
+		panic(fmt.Sprintf("method %s not found in %v", method, f.client.Type()))
+	}
+
+	mt := m.Type()
+	fn := reflect.MakeFunc(mt, func(args []reflect.Value) []reflect.Value {
+		f.mu.Lock()
+		result, err := op(args)
+		f.mu.Unlock()
+
+		return f.results(mt, result, err)
+	})
+
+	f.expect(&MockOptions{
+		Call:        f.recorder.MethodByName(method).Interface(),
+		AnyTimes:    true,
+		DoAndReturn: fn.Interface(),
+	})
+
+	return f
+}
+
+// results converts the outcome of an operation into the results of the
+// method.
+func (f *Fake[K, V]) results(mt reflect.Type, result interface{}, err error) []reflect.Value {
+	values := make([]reflect.Value, mt.NumOut())
+	for i := range values {
+		out := mt.Out(i)
+		values[i] = reflect.Zero(out)
+
+		switch {
+		case out == errorType:
+			if err != nil {
+				values[i] = reflect.ValueOf(err)
+			}
+		case err != nil || result == nil:
+		case reflect.TypeOf(result).AssignableTo(out):
+			values[i] = reflect.ValueOf(result)
+		}
+	}
+
+	return values
+}
+
+// fits tells if the arguments and results of the method fit an operation.
+func (f *Fake[K, V]) fits(method, wire string) bool {
+	mt := f.client.MethodByName(method).Type()
+	itemType := reflect.TypeOf((*V)(nil)).Elem()
+
+	resultType := itemType
+	if wire == "List" {
+		resultType = reflect.TypeOf([]V{})
+	}
+
+	// Every result must be an error or hold the result of the operation,
+	// so that methods merely returning errors are not wired.
+	for i := 0; i < mt.NumOut(); i++ {
+		if out := mt.Out(i); out != errorType && !resultType.AssignableTo(out) {
+			return false
+		}
+	}
+
+	switch wire {
+	case "Get", "Delete":
+		return hasArgOf(mt, reflect.TypeOf((*K)(nil)).Elem()) || hasArgOf(mt, itemType)
+	case "Create", "Update":
+		return hasArgOf(mt, itemType)
+	}
+
+	return true
+}
+
+// keyOf returns the key received by a method, given directly or through an
+// item.
+func (f *Fake[K, V]) keyOf(args []reflect.Value) (K, bool) {
+	for _, arg := range args {
+		if k, ok := arg.Interface().(K); ok && !isContext(arg) {
+			return k, true
+		}
+	}
+
+	if item, ok := f.itemOf(args); ok {
+		return f.key(item), true
+	}
+
+	var zero K
+	return zero, false
+}
+
+// itemOf returns the item received by a method.
+func (f *Fake[K, V]) itemOf(args []reflect.Value) (V, bool) {
+	for _, arg := range args {
+		if item, ok := arg.Interface().(V); ok && !isContext(arg) {
+			return item, true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (f *Fake[K, V]) missing(method, what string) error {
+	return fmt.Errorf("mocks: no %s found in the arguments of %s", what, method)
+}
+
+// store stores a copy of the item, returning it.
+func (f *Fake[K, V]) store(item V) V {
+	k := f.key(item)
+	if _, ok := f.items[k]; !ok {
+		f.keys = append(f.keys, k)
+	}
+
+	item = cloneItem(item)
+	f.items[k] = item
+	return item
+}
+
+func (f *Fake[K, V]) list() []V {
+	items := make([]V, len(f.keys))
+	for i, k := range f.keys {
+		items[i] = cloneItem(f.items[k])
+	}
+
+	return items
+}
+
+// cloneItem returns a shallow copy of the struct pointed to by item, or a
+// deep copy for protobuf messages, so that the stored items are not shared
+// with the code under test.
+func cloneItem[V any](item V) V {
+	if m, ok := any(item).(proto.Message); ok {
+		if c, ok := proto.Clone(m).(V); ok {
+			return c
+		}
+	}
+
+	v := reflect.ValueOf(item)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return item
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(V)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasArgOf(mt reflect.Type, typ reflect.Type) bool {
+	for i := 0; i < mt.NumIn(); i++ {
+		if in := mt.In(i); in != contextType && typ.AssignableTo(in) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isContext(v reflect.Value) bool {
+	_, ok := v.Interface().(context.Context)
+	return ok
+}
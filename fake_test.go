@@ -0,0 +1,181 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func exampleKey(e *example.Example) string {
+	return e.Id
+}
+
+func TestFake(t *testing.T) {
+	t.Run("should return the created items from later calls", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleRepository,
+		)
+
+		NewFake(mock, exampleKey).
+			Get("GetExample").
+			List("ListExamples").
+			Create("CreateExample").
+			Update("UpdateExample").
+			Delete("DeleteExample")
+
+		repo := mock.Client()
+
+		_, err := repo.GetExample(ctx, "1")
+		a.ErrorIs(err, ErrNotFound)
+		a.Equal(codes.NotFound, status.Code(err))
+
+		created, err := repo.CreateExample(ctx, &example.Example{Id: "1", Value: "first"})
+		a.NoError(err)
+		a.Equal(&example.Example{Id: "1", Value: "first"}, created)
+
+		_, err = repo.CreateExample(ctx, &example.Example{Id: "1", Value: "again"})
+		a.ErrorIs(err, ErrAlreadyExists)
+
+		_, err = repo.CreateExample(ctx, &example.Example{Id: "2", Value: "second"})
+		a.NoError(err)
+
+		got, err := repo.GetExample(ctx, "1")
+		a.NoError(err)
+		a.Equal(&example.Example{Id: "1", Value: "first"}, got)
+
+		updated, err := repo.UpdateExample(ctx, &example.Example{Id: "1", Value: "updated"})
+		a.NoError(err)
+		a.Equal("updated", updated.Value)
+
+		_, err = repo.UpdateExample(ctx, &example.Example{Id: "3"})
+		a.ErrorIs(err, ErrNotFound)
+
+		a.NoError(repo.DeleteExample(ctx, "2"))
+		a.ErrorIs(repo.DeleteExample(ctx, "2"), ErrNotFound)
+
+		all, err := repo.ListExamples(ctx)
+		a.NoError(err)
+		a.Equal([]*example.Example{{Id: "1", Value: "updated"}}, all)
+	})
+
+	t.Run("should wire the methods by their names", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleRepository,
+		)
+
+		NewFake(mock, exampleKey).
+			Put(&example.Example{Id: "1", Value: "seeded"}).
+			Auto()
+
+		repo := mock.Client()
+
+		got, err := repo.GetExample(ctx, "1")
+		a.NoError(err)
+		a.Equal("seeded", got.Value)
+
+		_, err = repo.CreateExample(ctx, &example.Example{Id: "2"})
+		a.NoError(err)
+		_, err = repo.UpdateExample(ctx, &example.Example{Id: "2", Value: "updated"})
+		a.NoError(err)
+		a.NoError(repo.DeleteExample(ctx, "1"))
+
+		all, err := repo.ListExamples(ctx)
+		a.NoError(err)
+		a.Equal([]*example.Example{{Id: "2", Value: "updated"}}, all)
+	})
+
+	t.Run("should not share the stored items with the code under test", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleRepository,
+		)
+
+		fake := NewFake(mock, exampleKey).Auto()
+		repo := mock.Client()
+
+		in := &example.Example{Id: "1", Value: "original"}
+		_, err := repo.CreateExample(ctx, in)
+		a.NoError(err)
+		in.Value = "changed"
+
+		got, err := repo.GetExample(ctx, "1")
+		a.NoError(err)
+		got.Value = "changed too"
+
+		a.Equal([]*example.Example{{Id: "1", Value: "original"}}, fake.Items())
+	})
+
+	t.Run("should leave alone the methods whose results do not fit", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		NewFake(mock, exampleKey).Auto()
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		out, err := mock.Client().GetByString(ctx, "1")
+		a.NoError(err)
+		a.Equal("Mocked Output", out)
+	})
+
+	t.Run("should return a copy of the deleted item", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		fake := NewFake(mock, exampleKey).
+			Put(&example.Example{Id: "1", Value: "stored"}).
+			Delete("WithStruct")
+		stored := fake.items["1"]
+
+		got, err := mock.Client().WithStruct(ctx, &example.Example{Id: "1"})
+		a.NoError(err)
+		a.Equal(&example.Example{Id: "1", Value: "stored"}, got)
+		a.NotSame(stored, got)
+		a.Empty(fake.Items())
+	})
+
+	t.Run("should panic for unknown methods", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleRepository,
+		)
+
+		a.PanicsWithValue("method Find not found in *mock_example.MockExampleRepository", func() {
+			NewFake(mock, exampleKey).Get("Find")
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package mocks
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the error returned by calls perturbed by a
+// FaultProfile without errors of its own.
+var ErrInjectedFault = errors.New("mocks: injected fault")
+
+// FaultProfile describes the faults injected into the otherwise successful
+// calls of a mock client, to test how the code under test copes with them.
+type FaultProfile struct {
+	// Seed initializes the random source that decides which calls are
+	// perturbed. The same seed, given the same sequence of calls, always
+	// injects the same faults. If zero, a random seed is used, which is
+	// logged when the test fails so that the failure can be reproduced.
+	Seed int64
+
+	// Default sets the faults of the methods not found in Methods.
+	Default Faults
+
+	// Methods sets the faults of specific methods, by name.
+	Methods map[string]Faults
+}
+
+// Faults sets how the calls of a method are perturbed.
+type Faults struct {
+	// ErrorRate is the probability, from 0 to 1, of a call returning an
+	// error. It only applies to methods whose last result is an error.
+	ErrorRate float64
+
+	// Errors holds the errors that are randomly chosen when a call fails.
+	// If empty, ErrInjectedFault is used.
+	Errors []error
+
+	// MinLatency and MaxLatency set the range of the latency added to every
+	// call. Calls whose context is done while waiting return its error.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+
+	// PanicRate is the probability, from 0 to 1, of a call panicking with
+	// PanicValue.
+	PanicRate float64
+
+	// PanicValue is the value of the injected panics. If nil,
+	// ErrInjectedFault is used.
+	PanicValue interface{}
+}
+
+// InjectedFault describes a fault injected into a call.
+type InjectedFault struct {
+	// Call is the position of the call among all calls made to the mock
+	// client, starting at 1.
+	Call    int
+	Method  string
+	Latency time.Duration
+	Error   error
+	Panic   interface{}
+}
+
+// FaultInjector perturbs the calls of a mock client according to a
+// FaultProfile.
+type FaultInjector struct {
+	profile FaultProfile
+
+	mu     sync.Mutex
+	rand   *rand.Rand
+	faults []InjectedFault
+}
+
+// logger is implemented by test reporters able to log messages, like
+// *testing.T.
+type logger interface {
+	Logf(format string, args ...interface{})
+}
+
+// InjectFaults perturbs the otherwise successful calls of the mock client
+// according to the profile. Calls returning mocked errors are left as they
+// are.
+func (m *MockServiceClient[R, T]) InjectFaults(profile FaultProfile) *FaultInjector {
+	if profile.Seed == 0 {
+		profile.Seed = time.Now().UnixNano()
+	}
+
+	f := &FaultInjector{
+		profile: profile,
+		rand:    rand.New(rand.NewSource(profile.Seed)),
+	}
+
+	// The seed is logged right away, as the testing package only prints the
+	// logs of failed tests, unless verbose.
+	if l, ok := m.tracker.t.(logger); ok {
+		l.Logf("mocks: injecting faults with seed %d", profile.Seed)
+	}
+
+	m.tracker.use(f.perturb)
+	return f
+}
+
+// Seed returns the seed used to decide which calls are perturbed.
+func (f *FaultInjector) Seed() int64 {
+	return f.profile.Seed
+}
+
+// Faults returns all faults injected so far, in the order of the calls.
+func (f *FaultInjector) Faults() []InjectedFault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]InjectedFault{}, f.faults...)
+}
+
+func (f *FaultInjector) perturb(inv *invocation) {
+	if inv.failed() {
+		return
+	}
+
+	faults, ok := f.profile.Methods[inv.method]
+	if !ok {
+		faults = f.profile.Default
+	}
+
+	fault := f.decide(inv, faults)
+	if fault == nil {
+		return
+	}
+
+	if fault.Latency > 0 {
+		ctx := inv.context()
+
+		select {
+		case <-time.After(fault.Latency):
+		case <-ctx.Done():
+			if inv.canFail() {
+				inv.fail(ctx.Err())
+			}
+
+			return
+		}
+	}
+
+	if fault.Panic != nil {
+		panic(fault.Panic)
+	}
+
+	if fault.Error != nil {
+		inv.fail(fault.Error)
+	}
+}
+
+// decide draws the fault of a call. The same amount of random numbers is
+// always drawn, so that a given seed perturbs the same calls even when the
+// rates change.
+func (f *FaultInjector) decide(inv *invocation, faults Faults) *InjectedFault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	latency, errorDraw, errorIndex, panicDraw := f.rand.Float64(), f.rand.Float64(), f.rand.Int(), f.rand.Float64()
+
+	fault := InjectedFault{
+		Call:   inv.index,
+		Method: inv.method,
+	}
+
+	if faults.MaxLatency > 0 {
+		fault.Latency = faults.MinLatency + time.Duration(latency*float64(faults.MaxLatency-faults.MinLatency))
+	}
+
+	if panicDraw < faults.PanicRate {
+		fault.Panic = faults.PanicValue
+		if fault.Panic == nil {
+			fault.Panic = ErrInjectedFault
+		}
+	} else if inv.canFail() && errorDraw < faults.ErrorRate {
+		fault.Error = ErrInjectedFault
+		if len(faults.Errors) > 0 {
+			fault.Error = faults.Errors[errorIndex%len(faults.Errors)]
+		}
+	}
+
+	if fault.Latency == 0 && fault.Panic == nil && fault.Error == nil {
+		return nil
+	}
+
+	f.faults = append(f.faults, fault)
+	return &fault
+}
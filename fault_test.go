@@ -0,0 +1,214 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestInjectFaults(t *testing.T) {
+	// callMany calls GetByInt n times, returning the errors received.
+	callMany := func(profile FaultProfile, n int) ([]error, []InjectedFault) {
+		ctx := context.TODO()
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByInt,
+			AnyTimes: true,
+			Return:   42,
+		})
+
+		faults := mock.InjectFaults(profile)
+
+		errs := make([]error, n)
+		for i := range errs {
+			_, errs[i] = mock.Client().GetByInt(ctx, i)
+		}
+
+		return errs, faults.Faults()
+	}
+
+	t.Run("should replay the same faults from the same seed", func(t *testing.T) {
+		a := assert.New(t)
+
+		profile := FaultProfile{
+			Seed:    42,
+			Default: Faults{ErrorRate: 0.5},
+		}
+
+		firstErrors, firstFaults := callMany(profile, 50)
+		secondErrors, secondFaults := callMany(profile, 50)
+
+		a.NotEmpty(firstFaults)
+		a.Less(len(firstFaults), 50)
+		a.Equal(firstErrors, secondErrors)
+		a.Equal(firstFaults, secondFaults)
+
+		for _, f := range firstFaults {
+			a.Equal("GetByInt", f.Method)
+			a.ErrorIs(firstErrors[f.Call-1], ErrInjectedFault)
+		}
+	})
+
+	t.Run("should inject the errors configured for the method", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		expectedError := errors.New("unavailable")
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Times:  1,
+			Return: 42,
+		})
+
+		mock.InjectFaults(FaultProfile{
+			Methods: map[string]Faults{
+				"GetByString": {ErrorRate: 1, Errors: []error{expectedError}},
+			},
+		})
+
+		output, err := mock.Client().GetByString(ctx, "Hello World")
+		a.ErrorIs(err, expectedError)
+		a.Equal("", output)
+
+		number, err := mock.Client().GetByInt(ctx, 1)
+		a.NoError(err)
+		a.Equal(42, number)
+	})
+
+	t.Run("should not perturb calls returning mocked errors", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		expectedError := errors.New("mocked error")
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "",
+			Error:  expectedError,
+		})
+
+		faults := mock.InjectFaults(FaultProfile{
+			Default: Faults{ErrorRate: 1, PanicRate: 1},
+		})
+
+		_, err := mock.Client().GetByString(ctx, "Hello World")
+
+		a.ErrorIs(err, expectedError)
+		a.Empty(faults.Faults())
+	})
+
+	t.Run("should add latency and panic", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		mock.InjectFaults(FaultProfile{
+			Default: Faults{
+				MinLatency: 20 * time.Millisecond,
+				MaxLatency: 30 * time.Millisecond,
+				PanicRate:  1,
+				PanicValue: "boom",
+			},
+		})
+
+		start := time.Now()
+		a.PanicsWithValue("boom", func() {
+			_, _ = mock.Client().GetByString(ctx, "Hello World")
+		})
+		a.GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("should return the context error when it is done during latency", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		mock.InjectFaults(FaultProfile{
+			Default: Faults{MinLatency: time.Second, MaxLatency: time.Second},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := mock.Client().GetByString(ctx, "Hello World")
+
+		a.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	t.Run("should log the seed", func(t *testing.T) {
+		a := assert.New(t)
+		reporter := &loggingReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.InjectFaults(FaultProfile{Seed: 42})
+
+		a.Empty(reporter.finish())
+		a.Equal([]string{"mocks: injecting faults with seed 42"}, reporter.logs)
+	})
+}
+
+// loggingReporter is a fakeReporter that also records the logged messages.
+type loggingReporter struct {
+	fakeReporter
+	logs []string
+}
+
+func (l *loggingReporter) Logf(format string, args ...any) {
+	l.logs = append(l.logs, fmt.Sprintf(format, args...))
+}
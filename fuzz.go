@@ -0,0 +1,87 @@
+package mocks
+
+import (
+	"encoding/binary"
+	"reflect"
+	"sync"
+)
+
+// FuzzGenerator is a Generator building values from a stream of bytes, such
+// as the inputs of a fuzz test, so that the fuzzer explores the responses
+// of the mocked services. Once the bytes run out, zero values are built,
+// with nil pointers and errors.
+//
+// Example:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//		mock := mocks.New(t, example_mock.NewMockExampleMock)
+//		mock.Mock(&mocks.MockOptions{
+//			Call:      mock.Recorder().WithStruct,
+//			AnyTimes:  true,
+//			Generator: mocks.NewFuzzGenerator(data),
+//		})
+//
+//		handler.Handle(ctx, mock.Client())
+//	})
+type FuzzGenerator struct {
+	mu      sync.Mutex
+	data    []byte
+	builder builder
+}
+
+// NewFuzzGenerator returns a new generator consuming data.
+func NewFuzzGenerator(data []byte) *FuzzGenerator {
+	g := &FuzzGenerator{
+		data: data,
+	}
+
+	g.builder = newBuilder(fuzzSource{g})
+	return g
+}
+
+// Generate returns a new value of type typ built from the next bytes.
+func (g *FuzzGenerator) Generate(typ reflect.Type) reflect.Value {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.builder.build(typ)
+}
+
+// next consumes up to n bytes.
+func (g *FuzzGenerator) next(n int) []byte {
+	if n > len(g.data) {
+		n = len(g.data)
+	}
+
+	b := g.data[:n]
+	g.data = g.data[n:]
+	return b
+}
+
+// fuzzSource draws the primitive values of a FuzzGenerator from its bytes.
+type fuzzSource struct {
+	g *FuzzGenerator
+}
+
+func (s fuzzSource) Uint64() uint64 {
+	var b [8]byte
+	copy(b[:], s.g.next(8))
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (s fuzzSource) Choice(n int) int {
+	b := s.g.next(1)
+	if len(b) == 0 {
+		return 0
+	}
+
+	return int(b[0]) % n
+}
+
+func (s fuzzSource) Float64() float64 {
+	return float64(s.Uint64()>>11) / (1 << 53)
+}
+
+func (s fuzzSource) String(n int) string {
+	return string(s.g.next(n))
+}
@@ -0,0 +1,106 @@
+package mocks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestFuzzGenerator(t *testing.T) {
+	t.Run("should build the same values from the same bytes", func(t *testing.T) {
+		a := assert.New(t)
+		data := []byte("some fuzzer input that is long enough to build a few values")
+		typ := reflect.TypeOf(&example.Example{})
+
+		first := NewFuzzGenerator(data).Generate(typ).Interface()
+		second := NewFuzzGenerator(data).Generate(typ).Interface()
+
+		a.NotNil(first)
+		a.Equal(first, second)
+	})
+
+	t.Run("should build zero values once the bytes run out", func(t *testing.T) {
+		a := assert.New(t)
+		g := NewFuzzGenerator(nil)
+
+		a.Nil(g.Generate(reflect.TypeOf(&example.Example{})).Interface())
+		a.Nil(g.Generate(errorType).Interface())
+		a.Equal(0, g.Generate(reflect.TypeOf(0)).Interface())
+		a.Empty(g.Generate(reflect.TypeOf([]string{})).Interface())
+	})
+
+	t.Run("should return generated values from the mocked calls", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().WithStruct,
+			Times:     2,
+			Generator: NewFuzzGenerator([]byte{1, 4, 'i', 'd', '-', '1', 3, 'v', 'a', 'l', 0, 1, 2, 'i', 'd', 0, 1}),
+		})
+
+		out, err := mock.Client().WithStruct(ctx, &example.Example{})
+		a.NoError(err)
+		a.Equal(&example.Example{Id: "id-1", Value: "val"}, out)
+
+		out, err = mock.Client().WithStruct(ctx, &example.Example{})
+		a.ErrorIs(err, ErrGenerated)
+		a.Nil(out)
+	})
+
+	t.Run("should panic when used along with DoAndReturn", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("Generator can not be used along with Panic or DoAndReturn", func() {
+			mock.Mock(&MockOptions{
+				Call:        mock.Recorder().GetByString,
+				Generator:   NewFuzzGenerator(nil),
+				DoAndReturn: func(context.Context, string) (string, error) { return "", nil },
+			})
+		})
+	})
+}
+
+func FuzzFuzzGenerator(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 4, 'i', 'd', '-', '1', 3, 'v', 'a', 'l', 1})
+	f.Add([]byte{1, 4, 'i', 'd', '-', '1', 3, 'v', 'a', 'l', 0})
+	f.Add([]byte("\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx := context.TODO()
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().WithStruct,
+			AnyTimes:  true,
+			Generator: NewFuzzGenerator(data),
+		})
+
+		out, err := mock.Client().WithStruct(ctx, &example.Example{})
+		if err != nil && out != nil {
+			t.Fatalf("got %v along with error %v", out, err)
+		}
+	})
+}
@@ -0,0 +1,401 @@
+package mocks
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrGenerated is the error returned by the calls whose results are built
+// by a Generator that chose to fail.
+var ErrGenerated = errors.New("mocks: generated error")
+
+// Generator builds the values returned by the calls of expectations set up
+// with MockOptions.Generator, from the result types of the mocked method.
+type Generator interface {
+	// Generate returns a new value of type typ.
+	Generate(typ reflect.Type) reflect.Value
+}
+
+// Generate returns a new value of type T built by g, which can be used as
+// the Return value of an expectation.
+//
+// Example:
+//
+//	mock.Mock(&MockOptions{
+//		Call:   mock.Recorder().WithStruct,
+//		Times:  1,
+//		Return: mocks.Generate[*example.Example](gen),
+//	})
+func Generate[T any](g Generator) T {
+	var zero T
+	if v, ok := g.Generate(reflect.TypeOf(&zero).Elem()).Interface().(T); ok {
+		return v
+	}
+
+	return zero
+}
+
+// source provides the primitive values from which a builder builds values
+// of any type.
+type source interface {
+	Uint64() uint64
+
+	// Choice returns a number in [0, n), used for the small decisions such
+	// as lengths or nil pointers.
+	Choice(n int) int
+
+	// Float64 returns a number in [0, 1).
+	Float64() float64
+
+	// String returns a string of n characters at most.
+	String(n int) string
+}
+
+// builder builds values of any type from a source, walking structs,
+// pointers, slices, arrays and maps down to maxDepth levels. Only exported
+// struct fields are set, so that the internal state of values such as
+// protobuf messages is left untouched.
+//
+// The values of struct fields can be restricted with the "gen" tag, see
+// constraints.
+type builder struct {
+	src      source
+	maxLen   int
+	maxDepth int
+	maxFloat float64
+
+	// maxInt bounds the integers without a range to [-maxInt, maxInt], or
+	// [0, maxInt] if unsigned. They are drawn from the whole range of
+	// Uint64 if it is negative.
+	maxInt int
+
+	// custom builds the values of the types it knows, if set.
+	custom func(typ reflect.Type) (reflect.Value, bool)
+
+	// fails draws whether errors are built, instead of a coin flip, if set.
+	fails func() bool
+}
+
+func newBuilder(src source) builder {
+	return builder{
+		src:      src,
+		maxLen:   8,
+		maxDepth: 5,
+		maxFloat: 1e6,
+		maxInt:   -1,
+	}
+}
+
+func (b *builder) build(typ reflect.Type) reflect.Value {
+	v := reflect.New(typ).Elem()
+	b.fill(v, constraints{}, 0)
+	return v
+}
+
+// length draws the length of a string, slice or map.
+func (b *builder) length(c constraints) int {
+	min, max := c.minLen, b.maxLen
+	if c.maxLen > 0 {
+		max = c.maxLen
+	}
+
+	if max < min {
+		max = min
+	}
+
+	return min + b.src.Choice(max-min+1)
+}
+
+func (b *builder) fill(v reflect.Value, c constraints, depth int) {
+	if c.skip {
+		return
+	}
+
+	if b.custom != nil {
+		if value, ok := b.custom(v.Type()); ok {
+			v.Set(value)
+			return
+		}
+	}
+
+	if v.Type() == timeType {
+		v.Set(reflect.ValueOf(time.Unix(int64(b.src.Uint64()%(1<<33)), 0).UTC()))
+		return
+	}
+
+	if len(c.oneof) > 0 && isScalar(v.Kind()) {
+		setFromString(v, c.oneof[b.src.Choice(len(c.oneof))])
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(b.src.Choice(2) == 1)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if c.hasRange() {
+			lo, hi := c.bounds(b.maxFloat)
+			v.SetInt(int64(lo + math.Floor(b.src.Float64()*(math.Floor(hi)-lo+1))))
+			return
+		}
+
+		if b.maxInt >= 0 {
+			v.SetInt(int64(b.src.Choice(2*b.maxInt+1) - b.maxInt))
+			return
+		}
+
+		v.SetInt(int64(b.src.Uint64()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if c.hasRange() {
+			lo, hi := c.bounds(b.maxFloat)
+			lo = math.Max(lo, 0)
+			v.SetUint(uint64(lo + math.Floor(b.src.Float64()*(math.Floor(hi)-lo+1))))
+			return
+		}
+
+		if b.maxInt >= 0 {
+			v.SetUint(uint64(b.src.Choice(b.maxInt + 1)))
+			return
+		}
+
+		v.SetUint(b.src.Uint64())
+
+	case reflect.Float32, reflect.Float64:
+		if c.hasRange() {
+			lo, hi := c.bounds(b.maxFloat)
+			v.SetFloat(lo + b.src.Float64()*(hi-lo))
+			return
+		}
+
+		v.SetFloat((b.src.Float64()*2 - 1) * b.maxFloat)
+
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex((b.src.Float64()*2-1)*b.maxFloat, (b.src.Float64()*2-1)*b.maxFloat))
+
+	case reflect.String:
+		v.SetString(b.src.String(b.length(c)))
+
+	case reflect.Ptr:
+		// Pointers are nil a quarter of the time, unless required, and past
+		// the maximum depth, which stops recursive types.
+		if depth >= b.maxDepth || (b.src.Choice(4) == 0 && !c.required) {
+			return
+		}
+
+		p := reflect.New(v.Type().Elem())
+		b.fill(p.Elem(), c, depth+1)
+		v.Set(p)
+
+	case reflect.Slice:
+		if depth >= b.maxDepth {
+			return
+		}
+
+		n := b.length(c)
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			b.fill(s.Index(i), c.elements(), depth+1)
+		}
+
+		v.Set(s)
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			b.fill(v.Index(i), c.elements(), depth+1)
+		}
+
+	case reflect.Map:
+		if depth >= b.maxDepth {
+			return
+		}
+
+		n := b.length(c)
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			b.fill(key, constraints{}, depth+1)
+
+			elem := reflect.New(v.Type().Elem()).Elem()
+			b.fill(elem, c.elements(), depth+1)
+
+			m.SetMapIndex(key, elem)
+		}
+
+		v.Set(m)
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() {
+				b.fill(v.Field(i), parseConstraints(v.Type(), field), depth+1)
+			}
+		}
+
+	case reflect.Interface:
+		// Errors fail half of the time, unless drawn by fails, other
+		// interfaces are left nil as their implementations are unknown.
+		if v.Type() == errorType && b.failing() {
+			v.Set(reflect.ValueOf(ErrGenerated))
+		}
+	}
+}
+
+// failing draws whether an error is built.
+func (b *builder) failing() bool {
+	if b.fails != nil {
+		return b.fails()
+	}
+
+	return b.src.Choice(2) == 1
+}
+
+// constraints restrict the values built for a struct field. They are set
+// with the "gen" tag, as comma separated options: "-" leaves the field
+// unset, "required" never leaves pointers nil, "min=N" and "max=N" set the
+// range of numbers, "minlen=N" and "maxlen=N" the length of strings, slices
+// and maps, and "oneof=a|b|c" chooses the value among the given ones.
+// Ranges and choices also apply to the elements of slices, arrays and maps.
+type constraints struct {
+	skip           bool
+	required       bool
+	min, max       float64
+	hasMin, hasMax bool
+	minLen, maxLen int
+	oneof          []string
+}
+
+func parseConstraints(parent reflect.Type, field reflect.StructField) constraints {
+	var c constraints
+
+	tag, ok := field.Tag.Lookup("gen")
+	if !ok {
+		return c
+	}
+
+	for _, opt := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+
+		var err error
+		switch name {
+		case "-":
+			c.skip = true
+		case "required":
+			c.required = true
+		case "min":
+			c.min, err = strconv.ParseFloat(value, 64)
+			c.hasMin = true
+		case "max":
+			c.max, err = strconv.ParseFloat(value, 64)
+			c.hasMax = true
+		case "minlen":
+			c.minLen, err = strconv.Atoi(value)
+		case "maxlen":
+			c.maxLen, err = strconv.Atoi(value)
+		case "oneof":
+			c.oneof = strings.Split(value, "|")
+		default:
+			err = errors.New("unknown option")
+		}
+
+		if err != nil {
+			panic(fmt.Sprintf("invalid gen tag option %q of %v.%s: %v", opt, parent, field.Name, err))
+		}
+	}
+
+	return c
+}
+
+func (c constraints) hasRange() bool {
+	return c.hasMin || c.hasMax
+}
+
+// bounds returns the range of numbers, which spans maxSpan when only one of
+// its ends is set.
+func (c constraints) bounds(maxSpan float64) (float64, float64) {
+	switch {
+	case c.hasMin && c.hasMax:
+		return c.min, c.max
+	case c.hasMin:
+		return c.min, c.min + maxSpan
+	default:
+		return c.max - maxSpan, c.max
+	}
+}
+
+// elements returns the constraints applying to the elements of a field.
+func (c constraints) elements() constraints {
+	c.minLen, c.maxLen, c.required = 0, 0, false
+	return c
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// setFromString sets the scalar v to the value represented by s.
+func setFromString(v reflect.Value, s string) {
+	var err error
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, v.Type().Bits())
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, v.Type().Bits())
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, v.Type().Bits())
+		v.SetFloat(f)
+	}
+
+	if err != nil {
+		panic(fmt.Sprintf("invalid gen tag value %q for %v: %v", s, v.Type(), err))
+	}
+}
+
+// generatedResults returns the results of an expectation whose values are
+// built by g. Like most Go functions, calls returning an error return zero
+// values along with it.
+func generatedResults(mt reflect.Type, g Generator) func([]reflect.Value) []interface{} {
+	return func([]reflect.Value) []interface{} {
+		inv := &invocation{
+			methodType: mt,
+			rets:       make([]interface{}, mt.NumOut()),
+		}
+
+		for i := range inv.rets {
+			if v := g.Generate(mt.Out(i)); v.IsValid() && v.CanInterface() {
+				inv.rets[i] = v.Interface()
+			}
+		}
+
+		if inv.failed() {
+			inv.fail(inv.rets[len(inv.rets)-1].(error))
+		}
+
+		return inv.rets
+	}
+}
@@ -12,8 +12,13 @@ generate_mock() {
     local module=$3
 
     mockgen -source=$path/$filename.go -destination=$path/mock/$filename.go -package mock_$module
+    go run ./cmd/mockmeta -source=$path/$filename.go -destination=$path/mock/${filename}_metadata.go -package mock_$module
     echo "Mocks for $module generated"
 }
 
 # Generate mocks from container interfaces
-generate_mock "internal/example" "example" "example"
\ No newline at end of file
+generate_mock "internal/example" "example" "example"
+generate_mock "internal/example" "repository" "example"
+generate_mock "internal/example" "store" "example"
+generate_mock "internal/example" "publisher" "example"
+generate_mock "internal/greeter" "greeter" "greeter"
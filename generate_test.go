@@ -0,0 +1,84 @@
+package mocks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// maxSource always draws the largest values, so that every pointer is set
+// and every slice is full.
+type maxSource struct{}
+
+func (maxSource) Uint64() uint64 { return 7 }
+
+func (maxSource) Choice(n int) int { return n - 1 }
+
+func (maxSource) Float64() float64 { return 0.75 }
+
+func (maxSource) String(n int) string { return strings.Repeat("x", n) }
+
+type generated struct {
+	Name     string
+	Count    int8
+	Ratio    float64
+	Enabled  bool
+	Tags     []string
+	Labels   map[string]uint
+	Fixed    [2]int
+	Child    *generated
+	At       time.Time
+	Err      error
+	Any      interface{}
+	internal string
+}
+
+func TestBuilder(t *testing.T) {
+	t.Run("should build values of every kind", func(t *testing.T) {
+		a := assert.New(t)
+		b := newBuilder(maxSource{})
+		b.maxDepth = 2
+
+		v := b.build(reflect.TypeOf(generated{})).Interface().(generated)
+
+		a.Equal("xxxxxxxx", v.Name)
+		a.Equal(int8(7), v.Count)
+		a.Equal(0.5e6, v.Ratio)
+		a.True(v.Enabled)
+		a.Len(v.Tags, 8)
+		a.Len(v.Labels, 1)
+		a.Equal(uint(7), v.Labels["xxxxxxxx"])
+		a.Equal([2]int{7, 7}, v.Fixed)
+		a.Equal(time.Unix(7, 0).UTC(), v.At)
+		a.ErrorIs(v.Err, ErrGenerated)
+		a.Nil(v.Any)
+		a.Empty(v.internal)
+	})
+
+	t.Run("should stop at the maximum depth", func(t *testing.T) {
+		a := assert.New(t)
+		b := newBuilder(maxSource{})
+		b.maxDepth = 2
+
+		v := b.build(reflect.TypeOf(&generated{})).Interface().(*generated)
+
+		a.NotNil(v)
+		a.Nil(v.Child)
+		a.Nil(v.Tags)
+	})
+
+	t.Run("should only set the exported fields of protobuf messages", func(t *testing.T) {
+		a := assert.New(t)
+		b := newBuilder(maxSource{})
+
+		v := b.build(reflect.TypeOf(&wrapperspb.StringValue{})).Interface().(*wrapperspb.StringValue)
+
+		a.Equal("xxxxxxxx", v.GetValue())
+		a.True(proto.Equal(wrapperspb.String("xxxxxxxx"), v))
+	})
+}
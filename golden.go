@@ -0,0 +1,348 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UpdateGolden makes the golden files be written with the current call
+// logs, instead of compared with them. It is set when the MOCKS_UPDATE_GOLDEN
+// environment variable is not empty, i.e, MOCKS_UPDATE_GOLDEN=1 go test ./...
+var UpdateGolden = os.Getenv("MOCKS_UPDATE_GOLDEN") != ""
+
+// updateGolden tells if the golden files are written, which is also the
+// case when the test binary defines a boolean -update flag that is set:
+// go test ./... -update. The flag is looked up instead of registered, so
+// that it never clashes with the one of the tests.
+func updateGolden() bool {
+	if UpdateGolden {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+
+	update, _ := getter.Get().(bool)
+	return update
+}
+
+// goldenDir is the directory of the golden files, relative to the package
+// under test.
+var goldenDir = "testdata"
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+func (tr *tracker) logCall(inv *invocation) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.log = append(tr.log, inv)
+}
+
+// CallLog returns the calls made to the mock client so far, one per line in
+// the order they were made, with their arguments and results. Contexts are
+// shown as ctx, and pointers by the values they point to, so that the log
+// is the same across runs.
+//
+// Example:
+//
+//	fmt.Print(mock.CallLog())
+//	// 1. GetByString(ctx, "Mocked Input") = ("Mocked Output", nil)
+//	// 2. WithStruct(ctx, &{Id: "1", Value: ""}) = (nil, mocks: service is down)
+func (m *MockServiceClient[R, T]) CallLog() string {
+	tr := m.tracker
+
+	tr.mu.Lock()
+	log := append([]*invocation{}, tr.log...)
+	tr.mu.Unlock()
+
+	sort.Slice(log, func(i, j int) bool {
+		return log[i].index < log[j].index
+	})
+
+	var b strings.Builder
+	for i, inv := range log {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, inv.format())
+	}
+
+	return b.String()
+}
+
+// AssertGolden compares the call log with the golden file
+// testdata/<name>.golden, failing the test with the differences between
+// them. When the tests run with -update, or UpdateGolden is set, the
+// golden file is written instead. It returns whether the call log matched.
+func (m *MockServiceClient[R, T]) AssertGolden(name string) bool {
+	tr := m.tracker
+	tr.t.Helper()
+
+	path := filepath.Join(goldenDir, name+".golden")
+	got := m.CallLog()
+
+	if updateGolden() {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(got), 0o644)
+		}
+
+		if err != nil {
+			tr.t.Errorf("mocks: could not update %s: %v", path, err)
+			return false
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		tr.t.Errorf("mocks: golden file %s does not exist, run the test with -update to create it", path)
+		return false
+	}
+
+	if err != nil {
+		tr.t.Errorf("mocks: could not read %s: %v", path, err)
+		return false
+	}
+
+	if string(want) == got {
+		return true
+	}
+
+	tr.t.Errorf(
+		"mocks: call log of %T differs from %s, run the test with -update to accept it:\n%s",
+		tr.client, path, lineDiff(string(want), got),
+	)
+
+	return false
+}
+
+// Golden makes the call log be compared with the golden file
+// testdata/<name>.golden when the test finishes, like AssertGolden.
+func (m *MockServiceClient[R, T]) Golden(name string) *MockServiceClient[R, T] {
+	c, ok := m.tracker.t.(cleanuper)
+	if !ok {
+		panic("Golden requires a test reporter with Cleanup, like *testing.T")
+	}
+
+	c.Cleanup(func() {
+		m.AssertGolden(name)
+	})
+
+	return m
+}
+
+func (inv *invocation) format() string {
+	call := inv.formatCall()
+	if inv.panicked {
+		return fmt.Sprintf("%s panicked: %s", call, formatLogged(inv.panicValue))
+	}
+
+	rets := make([]string, len(inv.rets))
+	for i, ret := range inv.rets {
+		rets[i] = formatLogged(ret)
+	}
+
+	return fmt.Sprintf("%s = (%s)", call, strings.Join(rets, ", "))
+}
+
+// formatCall formats the method and the arguments of the call.
+func (inv *invocation) formatCall() string {
+	args := make([]string, len(inv.args))
+	for i, arg := range inv.args {
+		args[i] = formatLogged(arg)
+	}
+
+	return fmt.Sprintf("%s(%s)", inv.method, strings.Join(args, ", "))
+}
+
+// formatLogged formats a value of the call log.
+func formatLogged(x interface{}) string {
+	if x == nil {
+		return "nil"
+	}
+
+	var b strings.Builder
+	(&logFormatter{b: &b, visited: make(map[uintptr]bool)}).format(reflect.ValueOf(x))
+	return b.String()
+}
+
+// logFormatter formats values deterministically, walking pointers instead
+// of showing their addresses.
+type logFormatter struct {
+	b       *strings.Builder
+	visited map[uintptr]bool
+}
+
+func (f *logFormatter) format(v reflect.Value) {
+	if !v.IsValid() {
+		f.b.WriteString("nil")
+		return
+	}
+
+	if v.CanInterface() && v.Type().Implements(contextType) {
+		f.b.WriteString("ctx")
+		return
+	}
+
+	if v.CanInterface() && v.Type().Implements(errorType) && (v.Kind() != reflect.Ptr || !v.IsNil()) {
+		f.b.WriteString(v.Interface().(error).Error())
+		return
+	}
+
+	if v.Type() == timeType && v.CanInterface() {
+		f.b.WriteString(v.Interface().(time.Time).Format(time.RFC3339Nano))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			f.b.WriteString("nil")
+			return
+		}
+
+		if f.visited[v.Pointer()] {
+			f.b.WriteString("&<cycle>")
+			return
+		}
+
+		f.visited[v.Pointer()] = true
+		defer delete(f.visited, v.Pointer())
+
+		f.b.WriteString("&")
+		f.format(v.Elem())
+
+	case reflect.Interface:
+		f.format(v.Elem())
+
+	case reflect.Struct:
+		// As when diffing, the unexported fields of protobuf messages hold
+		// their internal state.
+		isProto := reflect.PointerTo(v.Type()).Implements(protoMessageType)
+
+		f.b.WriteString("{")
+		first := true
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if isProto && !field.IsExported() {
+				continue
+			}
+
+			if !first {
+				f.b.WriteString(", ")
+			}
+
+			first = false
+			f.b.WriteString(field.Name + ": ")
+			f.format(v.Field(i))
+		}
+		f.b.WriteString("}")
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			f.b.WriteString("nil")
+			return
+		}
+
+		f.b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				f.b.WriteString(", ")
+			}
+
+			f.format(v.Index(i))
+		}
+		f.b.WriteString("]")
+
+	case reflect.Map:
+		if v.IsNil() {
+			f.b.WriteString("nil")
+			return
+		}
+
+		entries := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			var entry strings.Builder
+			sub := &logFormatter{b: &entry, visited: f.visited}
+			sub.format(k)
+			entry.WriteString(": ")
+			sub.format(v.MapIndex(k))
+			entries = append(entries, entry.String())
+		}
+
+		sort.Strings(entries)
+		f.b.WriteString("map[" + strings.Join(entries, ", ") + "]")
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			f.b.WriteString("nil")
+			return
+		}
+
+		f.b.WriteString("<" + v.Type().String() + ">")
+
+	default:
+		f.b.WriteString(formatValue(v))
+	}
+}
+
+// lineDiff returns the lines of want missing from got, prefixed by "-", and
+// the lines of got missing from want, prefixed by "+", along with the lines
+// they share, prefixed by spaces.
+func lineDiff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&out, "  %s\n", a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&out, "- %s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(&out, "+ %s\n", b[j])
+			j++
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,206 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+// orchestrate makes a few calls to the mock client, like the code under
+// test would.
+func orchestrate(client example.ExampleMock) {
+	ctx := context.TODO()
+
+	_, _ = client.GetByString(ctx, "Mocked Input")
+	_, _ = client.WithStruct(ctx, &example.Example{Id: "1", Value: "first"})
+	_, _ = client.GetWithVariadic(ctx, "id", "a", "b")
+	_, _ = recoverer(func() (string, error) {
+		return client.GetByString(ctx, "crash")
+	})
+}
+
+func mockOrchestration(t *testing.T, mock *MockServiceClient[example_mock.MockExampleMockMockRecorder, *example_mock.MockExampleMock]) {
+	mock.Mock(&MockOptions{
+		Call:   mock.Recorder().GetByString,
+		Input:  "Mocked Input",
+		Times:  1,
+		Return: "Mocked Output",
+	}).Mock(&MockOptions{
+		Call:   mock.Recorder().WithStruct,
+		Times:  1,
+		Return: &example.Example{Id: "1", Value: "stored"},
+	}).Mock(&MockOptions{
+		Call:   mock.Recorder().GetWithVariadic,
+		Times:  1,
+		Return: 0,
+		Error:  errors.New("not found"),
+	}).Mock(&MockOptions{
+		Call:  mock.Recorder().GetByString,
+		Input: "crash",
+		Times: 1,
+		Panic: "boom",
+	})
+}
+
+// comparing disables UpdateGolden until the test finishes.
+func comparing(t *testing.T) {
+	updating := UpdateGolden
+	UpdateGolden = false
+
+	t.Cleanup(func() {
+		UpdateGolden = updating
+	})
+}
+
+func TestGolden(t *testing.T) {
+	t.Run("should log every call with its arguments and results", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mockOrchestration(t, mock)
+		orchestrate(mock.Client())
+
+		a.Equal(
+			`1. GetByString(ctx, "Mocked Input") = ("Mocked Output", nil)
+2. WithStruct(ctx, &{Id: "1", Value: "first"}) = (&{Id: "1", Value: "stored"}, nil)
+3. GetWithVariadic(ctx, "id", ["a", "b"]) = (0, not found)
+4. GetByString(ctx, "crash") panicked: "boom"
+`,
+			mock.CallLog(),
+		)
+	})
+
+	t.Run("should match the golden file", func(t *testing.T) {
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		).Golden("orchestration")
+
+		mockOrchestration(t, mock)
+		orchestrate(mock.Client())
+	})
+
+	t.Run("should show the differences with the golden file", func(t *testing.T) {
+		a := assert.New(t)
+		comparing(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().GetByString,
+			Input:  "Mocked Input",
+			Times:  1,
+			Return: "Changed Output",
+		})
+
+		_, _ = mock.Client().GetByString(context.TODO(), "Mocked Input")
+
+		a.False(mock.AssertGolden("orchestration"))
+		failures := reporter.finish()
+		a.Contains(failures, "mocks: call log of *mock_example.MockExampleMock differs from testdata/orchestration.golden, run the test with -update to accept it:")
+		a.Contains(failures, `- 1. GetByString(ctx, "Mocked Input") = ("Mocked Output", nil)`)
+		a.Contains(failures, `+ 1. GetByString(ctx, "Mocked Input") = ("Changed Output", nil)`)
+		a.Contains(failures, `- 2. WithStruct(ctx, &{Id: "1", Value: "first"}) = (&{Id: "1", Value: "stored"}, nil)`)
+	})
+
+	t.Run("should write the golden file when updating", func(t *testing.T) {
+		a := assert.New(t)
+
+		dir, updating := goldenDir, UpdateGolden
+		goldenDir, UpdateGolden = filepath.Join(t.TempDir(), "testdata"), true
+		defer func() {
+			goldenDir, UpdateGolden = dir, updating
+		}()
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mockOrchestration(t, mock)
+		orchestrate(mock.Client())
+
+		a.True(mock.AssertGolden("updated"))
+
+		content, err := os.ReadFile(filepath.Join(goldenDir, "updated.golden"))
+		a.NoError(err)
+		a.Equal(mock.CallLog(), string(content))
+	})
+
+	t.Run("should write the golden file with the update flag of the tests", func(t *testing.T) {
+		a := assert.New(t)
+
+		dir, updating, commandLine := goldenDir, UpdateGolden, flag.CommandLine
+		goldenDir, UpdateGolden = filepath.Join(t.TempDir(), "testdata"), false
+		flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+		defer func() {
+			goldenDir, UpdateGolden, flag.CommandLine = dir, updating, commandLine
+		}()
+
+		update := flag.Bool("update", false, "update the golden files")
+		a.NoError(flag.CommandLine.Parse([]string{"-update"}))
+		a.True(*update)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mockOrchestration(t, mock)
+		orchestrate(mock.Client())
+
+		a.True(mock.AssertGolden("flagged"))
+
+		content, err := os.ReadFile(filepath.Join(goldenDir, "flagged.golden"))
+		a.NoError(err)
+		a.Equal(mock.CallLog(), string(content))
+	})
+
+	t.Run("should fail when the golden file does not exist", func(t *testing.T) {
+		a := assert.New(t)
+		comparing(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		a.False(mock.AssertGolden("missing"))
+		a.Contains(reporter.finish(), "mocks: golden file testdata/missing.golden does not exist, run the test with -update to create it")
+	})
+
+	t.Run("should leave the command line flags to the tests", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.Nil(flag.Lookup("update"))
+	})
+}
+
+func TestLineDiff(t *testing.T) {
+	t.Run("should show removed, added and shared lines", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.Equal(
+			"  a\n- b\n+ x\n  c\n+ d\n",
+			lineDiff("a\nb\nc\n", "a\nx\nc\nd\n"),
+		)
+	})
+}
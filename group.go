@@ -0,0 +1,114 @@
+package mocks
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Group gathers expectations that belong together, like the phases of a
+// test, so that they can be verified, cleared or disabled at once. The
+// expectations of a group may be set up on different mock clients.
+//
+// Example:
+//
+//	setup := mocks.NewGroup("setup")
+//	mock.Mock(&MockOptions{Call: mock.Recorder().GetByString, Times: 1, Group: setup})
+//	service.Start(ctx)
+//	require.NoError(t, setup.Verify())
+type Group struct {
+	name     string
+	disabled atomic.Bool
+
+	mu           sync.Mutex
+	expectations []*Expectation
+}
+
+// NewGroup returns a new, enabled, group of expectations.
+func NewGroup(name string) *Group {
+	return &Group{
+		name: name,
+	}
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// add makes the group responsible for the expectation. Its number of calls
+// is no longer verified by the controller, which can not tell when it is
+// disabled, but by the group and the report shown when the test finishes.
+func (g *Group) add(e *Expectation, call *gomock.Call, opts *MockOptions) {
+	if !e.tracked() {
+		panic("Group can only be used with the methods of the mock client")
+	}
+
+	// The controller no longer checks the number of calls, so the report run
+	// by Cleanup is the only verification of the expectation.
+	if _, ok := e.tracker.t.(cleanuper); !ok {
+		panic("Group requires a test reporter with Cleanup, like *testing.T, to verify its expectations")
+	}
+
+	if !opts.AnyTimes {
+		call.MinTimes(0).MaxTimes(opts.Times)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expectations = append(g.expectations, e)
+}
+
+// Verify returns an error describing the expectations of the group that
+// were not met so far, or nil if all of them were.
+func (g *Group) Verify() error {
+	g.mu.Lock()
+	expectations := append([]*Expectation{}, g.expectations...)
+	g.mu.Unlock()
+
+	var b strings.Builder
+	for _, e := range expectations {
+		if e.unmet() {
+			e.describe(&b)
+		}
+	}
+
+	if b.Len() == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("mocks: unmet expectations in group %q:\n%s", g.name, b.String())
+}
+
+// Clear removes all expectations from the group. They no longer match any
+// call, nor are they required to be met.
+func (g *Group) Clear() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, e := range g.expectations {
+		e.cleared.Store(true)
+	}
+
+	g.expectations = nil
+}
+
+// Disable makes the expectations of the group stop matching calls, and
+// stop being required to be met, until the group is enabled again.
+func (g *Group) Disable() {
+	g.disabled.Store(true)
+}
+
+// Enable makes the expectations of a disabled group match calls again.
+func (g *Group) Enable() {
+	g.disabled.Store(false)
+}
+
+// Enabled tells if the expectations of the group match calls.
+func (g *Group) Enabled() bool {
+	return !g.disabled.Load()
+}
@@ -0,0 +1,233 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("should verify the expectations of each group independently", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		setup := NewGroup("setup")
+		flow := NewGroup("flow")
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Input:  "setup",
+			Times:  1,
+			Return: "Mocked Output",
+			Group:  setup,
+		}).Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByInt,
+			Input:  42,
+			Times:  2,
+			Return: 42,
+			Group:  flow,
+		})
+
+		err := setup.Verify()
+		a.ErrorContains(err, `mocks: unmet expectations in group "setup":`)
+		a.ErrorContains(err, "GetByString set up at group_test.go:")
+		a.ErrorContains(err, "expected 1 call(s), got 0")
+
+		_, _ = mock.Client().GetByString(ctx, "setup")
+		_, _ = mock.Client().GetByInt(ctx, 42)
+
+		a.NoError(setup.Verify())
+		a.ErrorContains(flow.Verify(), "GetByInt set up at group_test.go:")
+		a.ErrorContains(flow.Verify(), "expected 2 call(s), got 1")
+
+		_, _ = mock.Client().GetByInt(ctx, 42)
+		a.NoError(flow.Verify())
+	})
+
+	t.Run("should not match nor require the expectations of a disabled group", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		teardown := NewGroup("teardown")
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+			Group:  teardown,
+		})
+
+		teardown.Disable()
+		a.False(teardown.Enabled())
+		a.NoError(teardown.Verify())
+
+		run(func() {
+			_, _ = mock.Client().GetByString(ctx, "Mocked Input")
+		})
+
+		a.Equal(0, mock.Calls("GetByString"))
+		failures := reporter.finish()
+		a.Contains(failures, "Unexpected call")
+		a.NotContains(failures, "unmet expectations")
+	})
+
+	t.Run("should match the expectations of a group enabled again", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		group := NewGroup("toggled")
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+			Group:  group,
+		})
+
+		group.Disable()
+		group.Enable()
+
+		out, err := mock.Client().GetByString(ctx, "Mocked Input")
+		a.NoError(err)
+		a.Equal("Mocked Output", out)
+		a.NoError(group.Verify())
+	})
+
+	t.Run("should forget the expectations of a cleared group", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		group := NewGroup("phase")
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "first",
+			Group:  group,
+		})
+
+		group.Clear()
+		a.NoError(group.Verify())
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "second",
+			Group:  group,
+		})
+
+		out, err := mock.Client().GetByString(ctx, "Mocked Input")
+		a.NoError(err)
+		a.Equal("second", out)
+		a.NoError(group.Verify())
+		a.Empty(reporter.finish())
+	})
+
+	t.Run("should keep the maximum number of calls", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+			Group:  NewGroup("once"),
+		})
+
+		run(func() {
+			_, _ = mock.Client().GetByString(ctx, "Mocked Input")
+			_, _ = mock.Client().GetByString(ctx, "Mocked Input")
+		})
+
+		a.Equal(1, mock.Calls("GetByString"))
+		a.Contains(reporter.finish(), "Unexpected call")
+	})
+
+	t.Run("should report the unmet expectations of enabled groups", func(t *testing.T) {
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+			Group:  NewGroup("forgotten"),
+		})
+
+		a.Contains(reporter.finish(), "GetByString: expected 1 call(s), got 0")
+	})
+
+	t.Run("should panic when the expectations can not be verified", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewWithCtrl(
+			gomock.NewController(finishedReporter{&fakeReporter{}}),
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("Group requires a test reporter with Cleanup, like *testing.T, to verify its expectations", func() {
+			mock.Mock(&MockOptions{
+				Call:   mock.Recorder().GetByString,
+				Times:  1,
+				Return: "Mocked Output",
+				Group:  NewGroup("unverified"),
+			})
+		})
+	})
+}
+
+// finishedReporter is a test reporter without Cleanup, whose controller is
+// finished by hand.
+type finishedReporter struct {
+	f *fakeReporter
+}
+
+func (r finishedReporter) Errorf(format string, args ...any) { r.f.Errorf(format, args...) }
+
+func (r finishedReporter) Fatalf(format string, args ...any) { r.f.Fatalf(format, args...) }
+
+func (r finishedReporter) Helper() {}
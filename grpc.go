@@ -0,0 +1,294 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// serverBufferSize is the size of the in-memory buffer used by the listener
+// between the fake server and its client connection.
+const serverBufferSize = 1024 * 1024
+
+var clientStreamType = reflect.TypeOf((*grpc.ClientStream)(nil)).Elem()
+
+// Server is an in-memory gRPC server whose handlers dispatch every received
+// call into the expectations of a MockServiceClient. It allows code that
+// only knows how to talk through a *grpc.ClientConn to be tested with the
+// same Mock(&MockOptions{...}) setup used for injectable clients.
+type Server struct {
+	client   reflect.Value
+	listener *bufconn.Listener
+	server   *grpc.Server
+	conn     *grpc.ClientConn
+}
+
+// NewServer starts a new in-memory gRPC server backed by the mock service
+// client and returns it already connected. The server is stopped when the
+// test finishes.
+//
+// Incoming calls are routed by method name, i.e, a call to
+// "/package.Service/Method" is dispatched to the Method of the mocked
+// client. Since the context received by the mock is the one created by the
+// server, expectations should usually leave MockOptions.Ctx empty.
+//
+// Example:
+// NewServer(*testing.T, mocks.New(*testing.T, subscriptionv1mock.NewMockSubscriptionServiceClient))
+func NewServer[R any, T ServiceClient[R]](
+	t *testing.T,
+	mock *MockServiceClient[R, T],
+) *Server {
+	t.Helper()
+
+	s := &Server{
+		client:   reflect.ValueOf(mock.Client()),
+		listener: bufconn.Listen(serverBufferSize),
+	}
+
+	s.server = grpc.NewServer(grpc.UnknownServiceHandler(s.handle))
+	go func() {
+		// Serve only returns when the server is stopped.
+		_ = s.server.Serve(s.listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		s.server.Stop()
+		t.Fatalf("could not connect to the mocked server: %v", err)
+	}
+
+	s.conn = conn
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Conn returns the client connection to the server, which should be given
+// to the code being tested.
+func (s *Server) Conn() *grpc.ClientConn {
+	return s.conn
+}
+
+// Close closes the client connection and stops the server.
+func (s *Server) Close() {
+	_ = s.conn.Close()
+	s.server.Stop()
+}
+
+func (s *Server) handle(_ interface{}, stream grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "mocks: could not retrieve the called method")
+	}
+
+	method := s.client.MethodByName(fullMethod[strings.LastIndex(fullMethod, "/")+1:])
+	if !method.IsValid() {
+		return status.Errorf(codes.Unimplemented, "mocks: method %s is not implemented by %s", fullMethod, s.client.Type())
+	}
+
+	if mt := method.Type(); mt.NumOut() == 2 && mt.Out(0).Implements(clientStreamType) {
+		return handleStream(stream, method)
+	}
+
+	return handleUnary(stream, method)
+}
+
+func handleUnary(stream grpc.ServerStream, method reflect.Value) error {
+	mt := method.Type()
+	if mt.NumIn() < 2 || mt.In(1).Kind() != reflect.Ptr || mt.NumOut() != 2 {
+		return status.Errorf(codes.Unimplemented, "mocks: %s is not an unary call", mt)
+	}
+
+	req := reflect.New(mt.In(1).Elem())
+	if err := stream.RecvMsg(req.Interface()); err != nil {
+		return err
+	}
+
+	out, err := callMocked(method, []reflect.Value{reflect.ValueOf(stream.Context()), req})
+	if err != nil {
+		return err
+	}
+
+	if err, _ := out[1].Interface().(error); err != nil {
+		return err
+	}
+
+	if out[0].IsNil() {
+		return status.Error(codes.Internal, "mocks: mocked call returned a nil response")
+	}
+
+	return stream.SendMsg(out[0].Interface())
+}
+
+// handleStream dispatches a streaming call, forwarding the messages sent by
+// the client to the stream returned by the mock, and the messages received
+// from it, e.g. the script of a Stream, back to the client.
+func handleStream(stream grpc.ServerStream, method reflect.Value) error {
+	mt := method.Type()
+	in := []reflect.Value{reflect.ValueOf(stream.Context())}
+
+	// Server-streaming calls also receive the single client request.
+	if mt.NumIn() == 3 {
+		req := reflect.New(mt.In(1).Elem())
+		if err := stream.RecvMsg(req.Interface()); err != nil {
+			return err
+		}
+
+		in = append(in, req)
+	}
+
+	out, err := callMocked(method, in)
+	if err != nil {
+		return err
+	}
+
+	if err, _ := out[1].Interface().(error); err != nil {
+		return err
+	}
+
+	if out[0].IsNil() {
+		return status.Error(codes.Internal, "mocks: mocked call returned a nil stream")
+	}
+
+	client := out[0]
+	forwarded := make(chan error, 1)
+	if send := client.MethodByName("Send"); send.IsValid() {
+		go func() {
+			// Closing forwarded also releases the response when a failure
+			// reported by the mocked stream stops this goroutine.
+			defer close(forwarded)
+			forwarded <- forwardRequests(stream, send, client.MethodByName("CloseSend"))
+		}()
+	} else {
+		close(forwarded)
+	}
+
+	// Client-streaming calls only respond after the client closes its side,
+	// or once the messages can no longer be forwarded.
+	if closeAndRecv := client.MethodByName("CloseAndRecv"); closeAndRecv.IsValid() {
+		select {
+		case err := <-forwarded:
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+
+		return sendResponse(stream, closeAndRecv.Call(nil))
+	}
+
+	recv := client.MethodByName("Recv")
+	for {
+		if err := sendResponse(stream, recv.Call(nil)); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// forwardRequests sends every message received from the client through
+// send until the client closes its side of the stream.
+func forwardRequests(stream grpc.ServerStream, send, closeSend reflect.Value) error {
+	for {
+		req := reflect.New(send.Type().In(0).Elem())
+		if err := stream.RecvMsg(req.Interface()); err != nil {
+			if errors.Is(err, io.EOF) {
+				closeSend.Call(nil)
+				return nil
+			}
+
+			return err
+		}
+
+		if err, _ := send.Call([]reflect.Value{req})[0].Interface().(error); err != nil {
+			return err
+		}
+	}
+}
+
+// sendResponse sends a (message, error) pair returned by a stream to the
+// client.
+func sendResponse(stream grpc.ServerStream, out []reflect.Value) error {
+	if err, _ := out[1].Interface().(error); err != nil {
+		return err
+	}
+
+	return stream.SendMsg(out[0].Interface())
+}
+
+// callMocked calls the mocked method in its own goroutine, so that failures
+// reported by the controller, which stop the calling goroutine, or panics
+// are returned to the client as errors instead of leaving it waiting.
+func callMocked(method reflect.Value, in []reflect.Value) (out []reflect.Value, err error) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				err = status.Errorf(codes.Internal, "mocks: mocked call panicked: %v", r)
+			}
+		}()
+
+		out = method.Call(in)
+	}()
+
+	<-done
+	if out == nil && err == nil {
+		err = status.Error(codes.Internal, "mocks: mocked call was aborted by the test")
+	}
+
+	return out, err
+}
+
+// protoMatcher matches protobuf messages using proto.Equal, since messages
+// decoded from the wire carry internal state that makes reflect.DeepEqual,
+// used by gomock.Eq, consider them different from the expected ones.
+type protoMatcher struct {
+	message proto.Message
+}
+
+func (p protoMatcher) Matches(x interface{}) bool {
+	m, ok := x.(proto.Message)
+	return ok && proto.Equal(p.message, m)
+}
+
+func (p protoMatcher) String() string {
+	return fmt.Sprintf("is equal to %v (%T)", p.message, p.message)
+}
+
+// matchProtoMessage replaces an input protobuf message by a matcher that
+// is able to compare it with the received one.
+func matchProtoMessage(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanInterface() {
+		return v
+	}
+
+	if m, ok := v.Interface().(proto.Message); ok {
+		return reflect.ValueOf(protoMatcher{message: m})
+	}
+
+	return v
+}
@@ -0,0 +1,198 @@
+package mocks
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/somatech1/mocks/internal/greeter"
+	greeter_mock "github.com/somatech1/mocks/internal/greeter/mock"
+)
+
+func TestServer(t *testing.T) {
+	t.Run("should dispatch unary calls to the mock", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().SayHello,
+			Times:  1,
+			Input:  []interface{}{&wrapperspb.StringValue{Value: "Hello World"}},
+			Return: &wrapperspb.StringValue{Value: "Mocked Output"},
+		})
+
+		s := NewServer(t, mock)
+
+		output := &wrapperspb.StringValue{}
+		err := s.Conn().Invoke(ctx, greeter.SayHelloFullMethodName, wrapperspb.String("Hello World"), output)
+
+		a.NoError(err)
+		a.Equal("Mocked Output", output.GetValue())
+	})
+
+	t.Run("should return the mocked error to the client", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:  mock.Recorder().SayHello,
+			Times: 1,
+			Error: status.Error(codes.NotFound, "mocked error"),
+		})
+
+		s := NewServer(t, mock)
+
+		output := &wrapperspb.StringValue{}
+		err := s.Conn().Invoke(ctx, greeter.SayHelloFullMethodName, wrapperspb.String("Hello World"), output)
+
+		a.Error(err)
+		a.Equal(codes.NotFound, status.Code(err))
+		a.Equal("mocked error", status.Convert(err).Message())
+	})
+
+	t.Run("should return unimplemented for unknown methods", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		s := NewServer(t, mock)
+
+		output := &wrapperspb.StringValue{}
+		err := s.Conn().Invoke(ctx, "/greeter.Greeter/Unknown", wrapperspb.String("Hello World"), output)
+
+		a.Error(err)
+		a.Equal(codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("should dispatch server-streaming calls to the mock", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:  mock.Recorder().SayHelloStream,
+			Times: 1,
+			Input: []interface{}{wrapperspb.String("Hello World")},
+			Return: NewStream[wrapperspb.StringValue, wrapperspb.StringValue](ctx).
+				Respond(wrapperspb.String("First"), wrapperspb.String("Second")),
+		})
+
+		s := NewServer(t, mock)
+
+		stream, err := s.Conn().NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, greeter.SayHelloStreamFullMethodName)
+		a.NoError(err)
+		a.NoError(stream.SendMsg(wrapperspb.String("Hello World")))
+		a.NoError(stream.CloseSend())
+
+		var values []string
+		for {
+			output := &wrapperspb.StringValue{}
+			if err := stream.RecvMsg(output); err != nil {
+				a.ErrorIs(err, io.EOF)
+				break
+			}
+
+			values = append(values, output.GetValue())
+		}
+
+		a.Equal([]string{"First", "Second"}, values)
+	})
+
+	t.Run("should dispatch client-streaming calls to the mock", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		mocked := NewStream[wrapperspb.StringValue, wrapperspb.StringValue](ctx).
+			Respond(wrapperspb.String("Mocked Output"))
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().CollectHellos,
+			Times:  1,
+			Return: mocked,
+		})
+
+		s := NewServer(t, mock)
+
+		stream, err := s.Conn().NewStream(ctx, &grpc.StreamDesc{ClientStreams: true}, greeter.CollectHellosFullMethodName)
+		a.NoError(err)
+		a.NoError(stream.SendMsg(wrapperspb.String("Hello")))
+		a.NoError(stream.SendMsg(wrapperspb.String("World")))
+		a.NoError(stream.CloseSend())
+
+		output := &wrapperspb.StringValue{}
+		a.NoError(stream.RecvMsg(output))
+		a.Equal("Mocked Output", output.GetValue())
+		a.Len(mocked.Sent(), 2)
+		a.True(mocked.SendClosed())
+	})
+
+	t.Run("should respond to client-streaming calls when the messages stop being forwarded", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:  mock.Recorder().CollectHellos,
+			Times: 1,
+			Return: stoppedStream{
+				NewStream[wrapperspb.StringValue, wrapperspb.StringValue](ctx).Respond(wrapperspb.String("Mocked Output")),
+			},
+		})
+
+		s := NewServer(t, mock)
+
+		stream, err := s.Conn().NewStream(ctx, &grpc.StreamDesc{ClientStreams: true}, greeter.CollectHellosFullMethodName)
+		a.NoError(err)
+		a.NoError(stream.SendMsg(wrapperspb.String("Hello")))
+		a.NoError(stream.CloseSend())
+
+		output := &wrapperspb.StringValue{}
+		a.NoError(stream.RecvMsg(output))
+		a.Equal("Mocked Output", output.GetValue())
+	})
+}
+
+// stoppedStream stops the goroutine sending it messages, like a mocked
+// stream failing the test on an unexpected Send does.
+type stoppedStream struct {
+	*Stream[wrapperspb.StringValue, wrapperspb.StringValue]
+}
+
+func (stoppedStream) Send(*wrapperspb.StringValue) error {
+	runtime.Goexit()
+	return nil
+}
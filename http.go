@@ -0,0 +1,357 @@
+package mocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// HTTPMock is an http.RoundTripper that responds to requests according to
+// the expectations declared through Mock. Like MockServiceClient, the
+// expectations are verified by a gomock controller when the test finishes.
+type HTTPMock struct {
+	ctrl *gomock.Controller
+}
+
+// HTTPOptions provides all available options that a mocked request might
+// have.
+type HTTPOptions struct {
+	// Method is the HTTP method that the request should have. If empty,
+	// any method is accepted.
+	Method string
+
+	// Path is the URL path that the request should have. It can be a string
+	// or a gomock.Matcher receiving the path. If nil, any path is accepted.
+	Path interface{}
+
+	// Header holds the headers that the request should have. Each value can
+	// be a string or a gomock.Matcher receiving the header value. Headers
+	// that are not declared are not checked.
+	Header map[string]interface{}
+
+	// Body is the body that the request should have. It can be a string, a
+	// slice of bytes or a gomock.Matcher receiving the body as a string. If
+	// nil, any body is accepted.
+	Body interface{}
+
+	// AnyTimes is boolean flag to set that the request can be made 0 or more
+	// times.
+	AnyTimes bool
+
+	// Times represents the number of times that the request is going to be
+	// made.
+	Times int
+
+	// Status is the status code of the response. If omitted,
+	// http.StatusOK is used.
+	Status int
+
+	// ResponseHeader holds the headers of the response.
+	ResponseHeader http.Header
+
+	// Return points to the body of the response. It can be a string, a slice
+	// of bytes or any other value, which is encoded as JSON. When it is an
+	// *http.Response, a copy of it is returned, with its body, which is read
+	// when the request is mocked.
+	Return interface{}
+
+	// Error points to the error returned by the round trip, if that is the
+	// desired behavior.
+	Error error
+
+	// Delay sets how long the round trip takes before responding. The
+	// request context is still honored while waiting.
+	Delay time.Duration
+}
+
+// NewHTTP returns a new HTTP mock whose expectations are verified when the
+// test finishes.
+func NewHTTP(t *testing.T) *HTTPMock {
+	return NewHTTPWithCtrl(gomock.NewController(t))
+}
+
+// NewHTTPWithCtrl returns a new HTTP mock using an existing controller,
+// which can be shared with other mocks.
+func NewHTTPWithCtrl(ctrl *gomock.Controller) *HTTPMock {
+	return &HTTPMock{
+		ctrl: ctrl,
+	}
+}
+
+// Client returns an http.Client that sends its requests to the mock.
+func (h *HTTPMock) Client() *http.Client {
+	return &http.Client{
+		Transport: h,
+	}
+}
+
+// RoundTrip implements http.RoundTripper by finding the expectation that
+// matches the request.
+func (h *HTTPMock) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.ctrl.T.Helper()
+
+	// The body is buffered in a clone of the request, which must not be
+	// modified, so that every expectation can read it while looking for a
+	// match.
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	ret := h.ctrl.Call(h, "RoundTrip", clone)
+	res, _ := ret[0].(*http.Response)
+	err, _ = ret[1].(error)
+
+	if res != nil && res.Request == clone {
+		res.Request = req
+	}
+
+	return res, err
+}
+
+// Mock declares a request that is expected to be made, and how it should be
+// responded.
+func (h *HTTPMock) Mock(opts *HTTPOptions) *HTTPMock {
+	h.ctrl.T.Helper()
+
+	call := h.ctrl.RecordCallWithMethodType(
+		h,
+		"RoundTrip",
+		reflect.TypeOf((*HTTPMock)(nil).RoundTrip),
+		&requestMatcher{opts: opts},
+	)
+
+	// The body of a mocked *http.Response is read once, so that every call
+	// gets a copy of the response with a body of its own.
+	var body []byte
+	if res, ok := opts.Return.(*http.Response); ok && res.Body != nil {
+		b, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			h.ctrl.T.Fatalf("mocks: could not read the body of the mocked response: %v", err)
+		}
+
+		body = b
+	}
+
+	call.DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		if opts.Delay > 0 {
+			select {
+			case <-time.After(opts.Delay):
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+		}
+
+		if opts.Error != nil {
+			return nil, opts.Error
+		}
+
+		if res, ok := opts.Return.(*http.Response); ok {
+			return copyResponse(req, res, body), nil
+		}
+
+		return makeResponse(req, opts)
+	})
+
+	if opts.AnyTimes {
+		call.AnyTimes()
+		return h
+	}
+
+	call.Times(opts.Times)
+	return h
+}
+
+func makeResponse(req *http.Request, opts *HTTPOptions) (*http.Response, error) {
+	var body []byte
+	switch b := opts.Return.(type) {
+	case nil:
+	case string:
+		body = []byte(b)
+	case []byte:
+		body = b
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("mocks: could not encode the response body: %w", err)
+		}
+
+		body = encoded
+	}
+
+	status := opts.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header := opts.ResponseHeader.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// copyResponse returns a copy of a mocked response with the given body,
+// responding to req unless it already has a request.
+func copyResponse(req *http.Request, res *http.Response, body []byte) *http.Response {
+	c := *res
+	c.Header = res.Header.Clone()
+	c.Trailer = res.Trailer.Clone()
+	c.Body = io.NopCloser(bytes.NewReader(body))
+	if c.Request == nil {
+		c.Request = req
+	}
+
+	return &c
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// requestMatcher matches an *http.Request against the request related
+// options of a mocked request.
+type requestMatcher struct {
+	opts *HTTPOptions
+}
+
+func (r *requestMatcher) Matches(x interface{}) bool {
+	req, ok := x.(*http.Request)
+	if !ok {
+		return false
+	}
+
+	if r.opts.Method != "" && !strings.EqualFold(r.opts.Method, req.Method) {
+		return false
+	}
+
+	if r.opts.Path != nil && !matchValue(r.opts.Path, req.URL.Path) {
+		return false
+	}
+
+	for name, value := range r.opts.Header {
+		if !matchValue(value, req.Header.Get(name)) {
+			return false
+		}
+	}
+
+	if r.opts.Body != nil {
+		body, err := requestBody(req)
+		if err != nil || !matchValue(r.opts.Body, body) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *requestMatcher) String() string {
+	method := r.opts.Method
+	if method == "" {
+		method = "ANY"
+	}
+
+	desc := fmt.Sprintf("%s %s", strings.ToUpper(method), describeValue(r.opts.Path, "any path"))
+
+	names := make([]string, 0, len(r.opts.Header))
+	for name := range r.opts.Header {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		desc += fmt.Sprintf(", header %s %s", name, describeValue(r.opts.Header[name], ""))
+	}
+
+	if r.opts.Body != nil {
+		desc += ", body " + describeValue(r.opts.Body, "")
+	}
+
+	return desc
+}
+
+// Got implements gomock.GotFormatter, printing only the relevant parts of
+// the received request.
+func (r *requestMatcher) Got(x interface{}) string {
+	req, ok := x.(*http.Request)
+	if !ok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+
+	body, _ := requestBody(req)
+	return fmt.Sprintf("%s %s, header %v, body %q", req.Method, req.URL.Path, req.Header, body)
+}
+
+func requestBody(req *http.Request) (string, error) {
+	if req.GetBody == nil {
+		return "", nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	return string(b), err
+}
+
+// matchValue compares a received string against an expected string, slice
+// of bytes or gomock.Matcher.
+func matchValue(expected interface{}, value string) bool {
+	switch e := expected.(type) {
+	case gomock.Matcher:
+		return e.Matches(value)
+	case []byte:
+		return string(e) == value
+	default:
+		return fmt.Sprint(e) == value
+	}
+}
+
+func describeValue(expected interface{}, empty string) string {
+	switch e := expected.(type) {
+	case nil:
+		return empty
+	case gomock.Matcher:
+		return e.String()
+	case []byte:
+		return fmt.Sprintf("%q", e)
+	default:
+		return fmt.Sprintf("%q", fmt.Sprint(e))
+	}
+}
@@ -0,0 +1,170 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/somatech1/mocks/internal/example"
+)
+
+func TestHTTPMock(t *testing.T) {
+	t.Run("should mock a request and return the body", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewHTTP(t)
+
+		mock.Mock(&HTTPOptions{
+			Method: http.MethodGet,
+			Path:   "/examples/1",
+			Header: map[string]interface{}{
+				"Authorization": "Bearer token",
+			},
+			Times:  1,
+			Return: &example.Example{Id: "1", Value: "Mocked Output"},
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "http://localhost/examples/1", nil)
+		a.NoError(err)
+		req.Header.Set("Authorization", "Bearer token")
+
+		res, err := mock.Client().Do(req)
+		a.NoError(err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		a.NoError(err)
+		a.Equal(http.StatusOK, res.StatusCode)
+		a.JSONEq(`{"Id": "1", "Value": "Mocked Output"}`, string(body))
+	})
+
+	t.Run("should match the request body and return the status", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewHTTP(t)
+
+		mock.Mock(&HTTPOptions{
+			Method: http.MethodPost,
+			Path:   "/examples",
+			Body:   `{"value":"Hello World"}`,
+			Times:  1,
+			Status: http.StatusCreated,
+		}).Mock(&HTTPOptions{
+			Method: http.MethodPost,
+			Path:   "/examples",
+			Body:   gomock.Cond(func(x any) bool { return strings.Contains(x.(string), "Another One") }),
+			Times:  1,
+			Status: http.StatusConflict,
+		})
+
+		res, err := mock.Client().Post("http://localhost/examples", "application/json", strings.NewReader(`{"value":"Another One"}`))
+		a.NoError(err)
+		a.Equal(http.StatusConflict, res.StatusCode)
+
+		res, err = mock.Client().Post("http://localhost/examples", "application/json", strings.NewReader(`{"value":"Hello World"}`))
+		a.NoError(err)
+		a.Equal(http.StatusCreated, res.StatusCode)
+	})
+
+	t.Run("should return the mocked error", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewHTTP(t)
+		expectedError := errors.New("mocked error")
+
+		mock.Mock(&HTTPOptions{
+			Path:     gomock.Any(),
+			AnyTimes: true,
+			Error:    expectedError,
+		})
+
+		_, err := mock.Client().Get("http://localhost/examples/1")
+
+		a.Error(err)
+		a.ErrorIs(err, expectedError)
+	})
+
+	t.Run("should honor the request context while delaying the response", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewHTTP(t)
+
+		mock.Mock(&HTTPOptions{
+			Method: http.MethodGet,
+			Times:  1,
+			Delay:  time.Second,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/examples/1", nil)
+		a.NoError(err)
+
+		_, err = mock.Client().Do(req)
+
+		a.Error(err)
+		a.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	t.Run("should return a copy of the mocked response on every call", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewHTTP(t)
+
+		mock.Mock(&HTTPOptions{
+			Method: http.MethodGet,
+			Times:  2,
+			Return: &http.Response{
+				StatusCode: http.StatusAccepted,
+				Header:     http.Header{"Content-Type": []string{"text/plain"}},
+				Body:       io.NopCloser(strings.NewReader("Mocked Output")),
+			},
+		})
+
+		for i := 0; i < 2; i++ {
+			res, err := mock.Client().Get("http://localhost/examples/1")
+			a.NoError(err)
+
+			body, err := io.ReadAll(res.Body)
+			a.NoError(err)
+			a.NoError(res.Body.Close())
+			a.Equal(http.StatusAccepted, res.StatusCode)
+			a.Equal("text/plain", res.Header.Get("Content-Type"))
+			a.Equal("Mocked Output", string(body))
+
+			res.Header.Set("Content-Type", "application/json")
+		}
+	})
+
+	t.Run("should not modify the request", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := NewHTTP(t)
+
+		mock.Mock(&HTTPOptions{
+			Method: http.MethodPost,
+			Body:   "Hello World",
+			Times:  1,
+		})
+
+		body := io.NopCloser(strings.NewReader("Hello World"))
+		req, err := http.NewRequest(http.MethodPost, "http://localhost/examples", nil)
+		a.NoError(err)
+		req.Body = body
+
+		res, err := mock.RoundTrip(req)
+		a.NoError(err)
+		a.Equal(http.StatusOK, res.StatusCode)
+		a.Same(req, res.Request)
+		a.Equal(body, req.Body)
+		a.Nil(req.GetBody)
+	})
+}
@@ -0,0 +1,143 @@
+package mocks
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// Inject sets the nil exported interface fields of the struct pointed to by
+// target to the mock clients of the registry that implement them, creating
+// them from the registered constructors when needed. Fields of the empty
+// interface type are skipped, and so are unexported fields, which are set
+// by InjectUnexported, unless tagged, which is reported as an error.
+//
+// The mock client of a field can be chosen by the name of its type with the
+// "mock" tag, i.e, `mock:"MockExampleMock"`, which is needed when many of
+// them implement the field. Fields tagged with `mock:"-"` are skipped.
+//
+// The fields that could not be set are described by the returned error,
+// while the others are set anyway.
+//
+// Example:
+//
+//	reg := mocks.NewRegistry(t)
+//	mocks.Register(reg, users_mock.NewMockUsersClient)
+//	svc := &Service{}
+//	require.NoError(t, reg.Inject(svc))
+func (r *Registry) Inject(target interface{}) error {
+	return r.inject(target, false)
+}
+
+// InjectUnexported is like Inject, but also sets the unexported fields,
+// writing them through package unsafe, for structs under test that keep
+// their dependencies private.
+func (r *Registry) InjectUnexported(target interface{}) error {
+	return r.inject(target, true)
+}
+
+func (r *Registry) inject(target interface{}, unexported bool) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mocks: can not inject into %T, expected a pointer to a struct", target)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unsatisfied []string
+	s := v.Elem()
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		tag, tagged := field.Tag.Lookup("mock")
+		if tag == "-" || field.Type.Kind() != reflect.Interface {
+			continue
+		}
+
+		if !field.IsExported() && !unexported {
+			// A tag means that the field is expected to be set.
+			if tagged {
+				unsatisfied = append(unsatisfied, fmt.Sprintf("  - %s (%s): unexported, use InjectUnexported", field.Name, field.Type))
+			}
+
+			continue
+		}
+
+		if !tagged && field.Type.NumMethod() == 0 {
+			continue
+		}
+
+		f := s.Field(i)
+		if !f.IsNil() {
+			continue
+		}
+
+		typ, err := r.resolve(field.Type, tag)
+		if err != nil {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("  - %s (%s): %v", field.Name, field.Type, err))
+			continue
+		}
+
+		if !field.IsExported() {
+			f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+		}
+
+		f.Set(reflect.ValueOf(r.create(typ).client))
+	}
+
+	if len(unsatisfied) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"mocks: could not inject %d field(s) of %T:\n%s",
+		len(unsatisfied), target, strings.Join(unsatisfied, "\n"),
+	)
+}
+
+// resolve returns the type of the registered mock client to set to a field
+// of type iface, named name if not empty. The registry must be locked.
+func (r *Registry) resolve(iface reflect.Type, name string) (reflect.Type, error) {
+	var found []reflect.Type
+	for _, typ := range r.provided {
+		if name != "" && !hasTypeName(typ, name) {
+			continue
+		}
+
+		if name != "" && !typ.Implements(iface) {
+			return nil, fmt.Errorf("%s does not implement it", typ)
+		}
+
+		if typ.Implements(iface) {
+			found = append(found, typ)
+		}
+	}
+
+	switch {
+	case len(found) == 1:
+		return found[0], nil
+	case len(found) > 1:
+		names := make([]string, len(found))
+		for i, typ := range found {
+			names[i] = typ.String()
+		}
+
+		return nil, fmt.Errorf("implemented by many mock clients, %s, choose one with the mock tag", strings.Join(names, ", "))
+	case name != "":
+		return nil, fmt.Errorf("no mock client named %q", name)
+	default:
+		return nil, errors.New("no mock client implements it")
+	}
+}
+
+// hasTypeName tells if typ, or the type it points to, is named name, with
+// or without its package.
+func hasTypeName(typ reflect.Type, name string) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ.Name() == name || typ.String() == name
+}
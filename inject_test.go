@@ -0,0 +1,133 @@
+package mocks
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+	"github.com/somatech1/mocks/internal/greeter"
+	greeter_mock "github.com/somatech1/mocks/internal/greeter/mock"
+)
+
+// aggregator is a struct under test depending on many clients.
+type aggregator struct {
+	Example example.ExampleMock
+	greeter greeter.GreeterClient
+	Named   example.ExampleMock `mock:"MockExampleMock"`
+	Skipped example.ExampleMock `mock:"-"`
+	Options interface{}
+	Count   int
+}
+
+func TestInject(t *testing.T) {
+	t.Run("should set the interface fields to the registered mock clients", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		Register(reg, example_mock.NewMockExampleMock)
+		Register(reg, greeter_mock.NewMockGreeterClient)
+		a.Empty(reg.Clients())
+
+		svc := &aggregator{}
+		a.NoError(reg.Inject(svc))
+
+		example := Get(reg, example_mock.NewMockExampleMock)
+
+		a.Same(example.Client(), svc.Example)
+		a.Same(example.Client(), svc.Named)
+		a.Nil(svc.greeter)
+		a.Nil(svc.Skipped)
+		a.Nil(svc.Options)
+
+		example.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   example.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		out, err := svc.Example.GetByString(ctx, "Mocked Input")
+		a.NoError(err)
+		a.Equal("Mocked Output", out)
+	})
+
+	t.Run("should keep the fields already set", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		Register(reg, example_mock.NewMockExampleMock)
+		Register(reg, greeter_mock.NewMockGreeterClient)
+
+		client := example_mock.NewMockExampleMock(reg.Controller())
+		svc := &aggregator{Example: client}
+		a.NoError(reg.Inject(svc))
+		a.Same(client, svc.Example)
+	})
+
+	t.Run("should describe the fields that could not be set", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		Register(reg, greeter_mock.NewMockGreeterClient)
+
+		svc := &struct {
+			Example example.ExampleMock
+			Named   example.ExampleMock `mock:"MockGreeterClient"`
+			Missing io.Reader           `mock:"MockReader"`
+			greeter greeter.GreeterClient
+		}{}
+
+		err := reg.Inject(svc)
+		a.ErrorContains(err, "mocks: could not inject 3 field(s) of *struct")
+		a.ErrorContains(err, "  - Example (example.ExampleMock): no mock client implements it")
+		a.ErrorContains(err, "  - Named (example.ExampleMock): *mock_greeter.MockGreeterClient does not implement it")
+		a.ErrorContains(err, `  - Missing (io.Reader): no mock client named "MockReader"`)
+		a.Nil(svc.greeter)
+	})
+
+	t.Run("should describe the tagged unexported fields", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		Register(reg, greeter_mock.NewMockGreeterClient)
+
+		svc := &struct {
+			greeter greeter.GreeterClient `mock:"MockGreeterClient"`
+		}{}
+
+		err := reg.Inject(svc)
+		a.ErrorContains(err, "mocks: could not inject 1 field(s) of *struct")
+		a.ErrorContains(err, "  - greeter (greeter.GreeterClient): unexported, use InjectUnexported")
+		a.Nil(svc.greeter)
+
+		a.NoError(reg.InjectUnexported(svc))
+		a.NotNil(svc.greeter)
+	})
+
+	t.Run("should also set the unexported fields when asked to", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		Register(reg, example_mock.NewMockExampleMock)
+		Register(reg, greeter_mock.NewMockGreeterClient)
+
+		svc := &aggregator{}
+		a.NoError(reg.InjectUnexported(svc))
+
+		a.Same(Get(reg, example_mock.NewMockExampleMock).Client(), svc.Example)
+		a.Same(Get(reg, greeter_mock.NewMockGreeterClient).Client(), svc.greeter)
+		a.Nil(svc.Skipped)
+	})
+
+	t.Run("should only inject into pointers to structs", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		a.EqualError(reg.Inject(aggregator{}), "mocks: can not inject into mocks.aggregator, expected a pointer to a struct")
+	})
+}
@@ -0,0 +1,167 @@
+package mocks
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"go.uber.org/mock/gomock"
+)
+
+// partialInput returns the inputs of a call to a recorder matching only the
+// arguments set by MockOptions.InputAt and MockOptions.InputNamed, while the
+// other ones, variadic arguments included unless set by Variadic, match
+// anything.
+func partialInput(
+	ctx reflect.Value,
+	call reflect.Value,
+	tr *tracker,
+	methodType reflect.Type,
+	opts *MockOptions,
+) []reflect.Value {
+	ct := call.Type()
+	fixed := ct.NumIn()
+	if ct.IsVariadic() {
+		fixed--
+	}
+
+	in := make([]reflect.Value, fixed)
+	in[0] = ctx
+	for i := 1; i < fixed; i++ {
+		in[i] = reflect.ValueOf(gomock.Any())
+	}
+
+	for pos, v := range opts.InputAt {
+		if pos < 1 || pos >= fixed {
+			panic(fmt.Sprintf("InputAt position %d is not one of the %d fixed argument(s) after the context", pos, fixed-1))
+		}
+
+		in[pos] = reflect.ValueOf(v)
+	}
+
+	if len(opts.InputNamed) > 0 {
+		method := tr.recordedMethod(call)
+		names, err := tr.parameterNames(method)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		for name, v := range opts.InputNamed {
+			pos := indexOf(names, name)
+			switch {
+			case pos < 0:
+				panic(fmt.Sprintf("parameter %q not found in %s, its parameters are %v", name, method, names))
+			case pos == 0:
+				panic(fmt.Sprintf("parameter %q is the context, set it with Ctx", name))
+			case pos >= fixed:
+				panic(fmt.Sprintf("parameter %q is variadic, set it with Variadic", name))
+			}
+
+			if _, ok := opts.InputAt[pos]; ok {
+				panic(fmt.Sprintf("parameter %q is set by both InputAt and InputNamed", name))
+			}
+
+			in[pos] = reflect.ValueOf(v)
+		}
+	}
+
+	if !ct.IsVariadic() {
+		if opts.Variadic != nil {
+			panic("Variadic can only be used with variadic methods")
+		}
+
+		return in
+	}
+
+	variadic := opts.Variadic
+	if variadic == nil {
+		variadic = VariadicAny()
+	}
+
+	return variadicInput(in, call, variadic, methodType)
+}
+
+// parameters caches the parameter names parsed from the source of the
+// mocks, by mock type and method name.
+var parameters sync.Map
+
+type parametersKey struct {
+	mock   reflect.Type
+	method string
+}
+
+// parameterNames returns the parameter names of a method of the client,
+// from its generated metadata, if any, or from its source.
+func (tr *tracker) parameterNames(method string) ([]string, error) {
+	if md := metadataOf(tr.client); md != nil {
+		if m, ok := md.Method(method); ok {
+			return m.ParamNames(), nil
+		}
+	}
+
+	return parameterNames(tr.client, method)
+}
+
+// parameterNames returns the parameter names of a method of a generated
+// mock, parsed from its source, which mockgen writes with the names of the
+// mocked interface.
+func parameterNames(mock interface{}, method string) ([]string, error) {
+	key := parametersKey{mock: reflect.TypeOf(mock), method: method}
+	if names, ok := parameters.Load(key); ok {
+		return names.([]string), nil
+	}
+
+	m, ok := key.mock.MethodByName(method)
+	if !ok {
+		return nil, fmt.Errorf(
+			"mocks: the parameter names of %s.%s are unknown, InputNamed requires a generated mock or its metadata",
+			key.mock, method,
+		)
+	}
+
+	fn := runtime.FuncForPC(m.Func.Pointer())
+	file, line := fn.FileLine(fn.Entry())
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("mocks: could not parse the source of %s to find its parameter names: %w", method, err)
+	}
+
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Recv == nil || fd.Name.Name != method {
+			continue
+		}
+
+		if fset.Position(fd.Pos()).Line > line || fset.Position(fd.End()).Line < line {
+			continue
+		}
+
+		var names []string
+		for _, field := range fd.Type.Params.List {
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+		}
+
+		parameters.Store(key, names)
+		return names, nil
+	}
+
+	return nil, fmt.Errorf("mocks: method %s not found in %s", method, file)
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+
+	return -1
+}
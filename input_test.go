@@ -0,0 +1,183 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestPartialInput(t *testing.T) {
+	t.Run("should match the arguments set by position and anything else", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		in := &example.Example{Id: "1"}
+		mock.Mock(&MockOptions{
+			Ctx:     ctx,
+			Call:    mock.Recorder().Any,
+			InputAt: map[int]interface{}{1: in},
+			Times:   2,
+			Return:  in,
+		})
+
+		out, err := mock.Client().Any(ctx, &example.Example{Id: "1"})
+		a.NoError(err)
+		a.Equal("1", out.Id)
+
+		_, err = mock.Client().Any(ctx, &example.Example{Id: "1"}, "a", "b")
+		a.NoError(err)
+	})
+
+	t.Run("should match the arguments set by parameter name", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:        ctx,
+			Call:       mock.Recorder().Any,
+			InputNamed: map[string]interface{}{"in": &example.Example{Id: "1"}},
+			AnyTimes:   true,
+			Return:     &example.Example{Id: "1"},
+		})
+
+		_, err := mock.Client().Any(ctx, &example.Example{Id: "1"}, "verbose")
+		a.NoError(err)
+
+		run(func() {
+			_, _ = mock.Client().Any(ctx, &example.Example{Id: "2"})
+		})
+
+		failures := reporter.finish()
+		a.Contains(failures, "Unexpected call")
+		a.Equal(1, mock.Calls("Any"))
+	})
+
+	t.Run("should match parameter names of generic mocks", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockStore[string],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:        ctx,
+			Call:       mock.Recorder().Get,
+			InputNamed: map[string]interface{}{"id": "1"},
+			Times:      1,
+			Return:     "one",
+		})
+
+		out, err := mock.Client().Get(ctx, "1")
+		a.NoError(err)
+		a.Equal("one", out)
+	})
+
+	t.Run("should combine partial inputs with variadic ones", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:        ctx,
+			Call:       mock.Recorder().GetWithVariadic,
+			InputNamed: map[string]interface{}{"id": "id"},
+			Variadic:   VariadicLen(1),
+			Times:      1,
+			Return:     1,
+		})
+
+		out, err := mock.Client().GetWithVariadic(ctx, "id", "a")
+		a.NoError(err)
+		a.Equal(1, out)
+	})
+
+	t.Run("should panic when the arguments can not be set", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("Input can not be used along with InputAt or InputNamed", func() {
+			mock.Mock(&MockOptions{
+				Call:    mock.Recorder().GetByString,
+				Input:   "x",
+				InputAt: map[int]interface{}{1: "x"},
+			})
+		})
+
+		a.PanicsWithValue("InputAt position 2 is not one of the 1 fixed argument(s) after the context", func() {
+			mock.Mock(&MockOptions{
+				Call:    mock.Recorder().GetByString,
+				InputAt: map[int]interface{}{2: "x"},
+			})
+		})
+
+		a.PanicsWithValue(`parameter "name" not found in GetByString, its parameters are [ctx str]`, func() {
+			mock.Mock(&MockOptions{
+				Call:       mock.Recorder().GetByString,
+				InputNamed: map[string]interface{}{"name": "x"},
+			})
+		})
+
+		a.PanicsWithValue(`parameter "options" is variadic, set it with Variadic`, func() {
+			mock.Mock(&MockOptions{
+				Call:       mock.Recorder().GetWithVariadic,
+				InputNamed: map[string]interface{}{"options": "x"},
+			})
+		})
+
+		a.PanicsWithValue(`parameter "str" is set by both InputAt and InputNamed`, func() {
+			mock.Mock(&MockOptions{
+				Call:       mock.Recorder().GetByString,
+				InputAt:    map[int]interface{}{1: "x"},
+				InputNamed: map[string]interface{}{"str": "x"},
+			})
+		})
+
+		a.PanicsWithValue("Variadic can only be used with variadic methods", func() {
+			mock.Mock(&MockOptions{
+				Call:     mock.Recorder().GetByString,
+				InputAt:  map[int]interface{}{1: "x"},
+				Variadic: VariadicAny(),
+			})
+		})
+	})
+}
+
+func TestParameterNames(t *testing.T) {
+	t.Run("should parse the parameter names from the mock source", func(t *testing.T) {
+		a := assert.New(t)
+		client := example_mock.NewMockExampleMock(gomock.NewController(t))
+
+		names, err := parameterNames(client, "GetWithVariadic")
+		a.NoError(err)
+		a.Equal([]string{"ctx", "id", "options"}, names)
+
+		_, err = parameterNames(client, "Unknown")
+		a.ErrorContains(err, "the parameter names of *mock_example.MockExampleMock.Unknown are unknown")
+	})
+}
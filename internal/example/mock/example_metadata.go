@@ -0,0 +1,111 @@
+// Code generated by mockmeta. DO NOT EDIT.
+// Source: internal/example/example.go
+
+package mock_example
+
+import "github.com/somatech1/mocks/metadata"
+
+// MockMetadata returns the metadata of the ExampleMock interface.
+func (m *MockExampleMock) MockMetadata() *metadata.Interface {
+	return exampleMockMetadata
+}
+
+var exampleMockMetadata = &metadata.Interface{
+	Name: "ExampleMock",
+	Doc:  "",
+	Methods: []metadata.Method{
+		{
+			Name:     "GetByString",
+			Doc:      "",
+			Variadic: false,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "str", Type: "string"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "string"},
+				{Name: "", Type: "error"},
+			},
+		},
+		{
+			Name:     "GetByInt",
+			Doc:      "",
+			Variadic: false,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "i", Type: "int"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "int"},
+				{Name: "", Type: "error"},
+			},
+		},
+		{
+			Name:     "GetWithVariadic",
+			Doc:      "",
+			Variadic: true,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "id", Type: "string"},
+				{Name: "options", Type: "...string"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "int"},
+				{Name: "", Type: "error"},
+			},
+		},
+		{
+			Name:     "SingleError",
+			Doc:      "",
+			Variadic: true,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "id", Type: "string"},
+				{Name: "options", Type: "...string"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "error"},
+			},
+		},
+		{
+			Name:     "WithStruct",
+			Doc:      "",
+			Variadic: false,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "in", Type: "*Example"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "*Example"},
+				{Name: "", Type: "error"},
+			},
+		},
+		{
+			Name:     "WithDoAndReturn",
+			Doc:      "",
+			Variadic: false,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "in", Type: "*Example"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "*Example"},
+				{Name: "", Type: "error"},
+			},
+		},
+		{
+			Name:     "Any",
+			Doc:      "",
+			Variadic: true,
+			Params: []metadata.Param{
+				{Name: "ctx", Type: "context.Context"},
+				{Name: "in", Type: "*Example"},
+				{Name: "options", Type: "...string"},
+			},
+			Results: []metadata.Param{
+				{Name: "", Type: "*Example"},
+				{Name: "", Type: "error"},
+			},
+		},
+	},
+}
@@ -0,0 +1,9 @@
+package example
+
+import "context"
+
+// Publisher sends messages of any type.
+type Publisher interface {
+	// Publish sends the message.
+	Publish(ctx context.Context, msg any) error
+}
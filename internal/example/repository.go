@@ -0,0 +1,11 @@
+package example
+
+import "context"
+
+type ExampleRepository interface {
+	GetExample(ctx context.Context, id string) (*Example, error)
+	ListExamples(ctx context.Context) ([]*Example, error)
+	CreateExample(ctx context.Context, in *Example) (*Example, error)
+	UpdateExample(ctx context.Context, in *Example) (*Example, error)
+	DeleteExample(ctx context.Context, id string) error
+}
@@ -0,0 +1,12 @@
+package example
+
+import "context"
+
+// Store keeps items of any type by their ids.
+type Store[T any] interface {
+	// Get returns the item with the id.
+	Get(ctx context.Context, id string) (T, error)
+	List(ctx context.Context) ([]T, error)
+	Save(ctx context.Context, items []T) error
+	Put(ctx context.Context, items ...T) error
+}
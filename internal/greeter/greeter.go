@@ -0,0 +1,30 @@
+package greeter
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	ServiceName = "greeter.Greeter"
+
+	SayHelloFullMethodName       = "/greeter.Greeter/SayHello"
+	SayHelloStreamFullMethodName = "/greeter.Greeter/SayHelloStream"
+	CollectHellosFullMethodName  = "/greeter.Greeter/CollectHellos"
+	ChatFullMethodName           = "/greeter.Greeter/Chat"
+)
+
+type (
+	Greeter_SayHelloStreamClient = grpc.ServerStreamingClient[wrapperspb.StringValue]
+	Greeter_CollectHellosClient  = grpc.ClientStreamingClient[wrapperspb.StringValue, wrapperspb.StringValue]
+	Greeter_ChatClient           = grpc.BidiStreamingClient[wrapperspb.StringValue, wrapperspb.StringValue]
+)
+
+type GreeterClient interface {
+	SayHello(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
+	SayHelloStream(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (Greeter_SayHelloStreamClient, error)
+	CollectHellos(ctx context.Context, opts ...grpc.CallOption) (Greeter_CollectHellosClient, error)
+	Chat(ctx context.Context, opts ...grpc.CallOption) (Greeter_ChatClient, error)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"fmt"
+
+	"github.com/somatech1/mocks/metadata"
+)
+
+// Metadata returns the metadata of the mocked interface, generated by the
+// mockmeta command next to the mock, or nil if it was not generated.
+func (m *MockServiceClient[R, T]) Metadata() *metadata.Interface {
+	return metadataOf(m.tracker.client)
+}
+
+func metadataOf(client interface{}) *metadata.Interface {
+	if p, ok := client.(metadata.Provider); ok {
+		return p.MockMetadata()
+	}
+
+	return nil
+}
+
+// argumentName describes an argument of a method by its position and, when
+// known, the name of its parameter, i.e, "argument 1 (in)". Arguments past
+// the last parameter are variadic ones.
+func (tr *tracker) argumentName(method string, index int) string {
+	names, err := tr.parameterNames(method)
+	if err != nil || len(names) == 0 {
+		return fmt.Sprintf("argument %d", index)
+	}
+
+	nameIndex := index
+	if nameIndex >= len(names) {
+		nameIndex = len(names) - 1
+	}
+
+	return fmt.Sprintf("argument %d (%s)", index, names[nameIndex])
+}
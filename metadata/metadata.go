@@ -0,0 +1,54 @@
+// Package metadata describes mocked interfaces with what reflection can not
+// see, such as the names of their parameters and their doc comments. It is
+// generated from the interface sources by the mockmeta command, next to the
+// mocks generated by mockgen, and consumed by the mocks package.
+package metadata
+
+// Interface describes a mocked interface.
+type Interface struct {
+	Name    string
+	Doc     string
+	Methods []Method
+}
+
+// Method describes a method of a mocked interface.
+type Method struct {
+	Name     string
+	Doc      string
+	Params   []Param
+	Results  []Param
+	Variadic bool
+}
+
+// Param describes a parameter, or a result, of a method. Its type is
+// written as in the source of the interface.
+type Param struct {
+	Name string
+	Type string
+}
+
+// Provider is implemented by the mocks whose metadata was generated.
+type Provider interface {
+	MockMetadata() *Interface
+}
+
+// Method returns the method with the name.
+func (i *Interface) Method(name string) (Method, bool) {
+	for _, m := range i.Methods {
+		if m.Name == name {
+			return m, true
+		}
+	}
+
+	return Method{}, false
+}
+
+// ParamNames returns the names of the parameters of the method, in order.
+func (m Method) ParamNames() []string {
+	names := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		names[i] = p.Name
+	}
+
+	return names
+}
@@ -0,0 +1,27 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterface(t *testing.T) {
+	t.Run("should find methods by name", func(t *testing.T) {
+		a := assert.New(t)
+
+		i := &Interface{
+			Name: "Greeter",
+			Methods: []Method{
+				{Name: "SayHello", Params: []Param{{Name: "ctx"}, {Name: "in"}}},
+			},
+		}
+
+		m, ok := i.Method("SayHello")
+		a.True(ok)
+		a.Equal([]string{"ctx", "in"}, m.ParamNames())
+
+		_, ok = i.Method("SayGoodbye")
+		a.False(ok)
+	})
+}
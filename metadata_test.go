@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestMetadata(t *testing.T) {
+	t.Run("should return the generated metadata of the mock", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockStore[string],
+		)
+
+		md := mock.Metadata()
+		a.Equal("Store", md.Name)
+		a.Equal("Store keeps items of any type by their ids.", md.Doc)
+
+		get, ok := md.Method("Get")
+		a.True(ok)
+		a.Equal("Get returns the item with the id.", get.Doc)
+		a.Equal([]string{"ctx", "id"}, get.ParamNames())
+		a.Equal("T", get.Results[0].Type)
+	})
+
+	t.Run("should return nil without generated metadata", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			NewDynamic[interface{ Get(context.Context) error }],
+		)
+
+		a.Nil(mock.Metadata())
+	})
+
+	t.Run("should name the argument of the closest call in reports", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().GetByString,
+			Input:  "expected",
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		run(func() {
+			_, _ = mock.Client().GetByString(ctx, "unexpected")
+		})
+
+		failures := reporter.finish()
+		a.Contains(failures, "closest call differs at argument 1 (str):")
+	})
+
+	t.Run("should name the variadic arguments after the last parameter", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.Equal("argument 2 (options)", mock.tracker.argumentName("GetWithVariadic", 2))
+		a.Equal("argument 4 (options)", mock.tracker.argumentName("GetWithVariadic", 4))
+	})
+}
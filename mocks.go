@@ -1,8 +1,12 @@
 package mocks
 
 import (
+	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 )
@@ -12,8 +16,14 @@ type (
 		EXPECT() *R
 	}
 
+	// MockServiceClient wraps a generated mock. It is safe to set up
+	// expectations and to call the mocked client from multiple goroutines,
+	// as long as each expectation is fully set up by Mock before the calls
+	// that it should match are made.
 	MockServiceClient[R any, T ServiceClient[R]] struct {
 		ServiceClient T
+
+		tracker *tracker
 	}
 
 	FnNewClientService[T any] func(*gomock.Controller) T
@@ -37,6 +47,23 @@ type MockOptions struct {
 	// internally.
 	Input interface{}
 
+	// Variadic sets the variadic arguments of the call apart from Input,
+	// which then only holds the fixed ones. It can be a slice of arguments,
+	// matched one by one, or a matcher of all of them, such as VariadicAny,
+	// VariadicLen or VariadicContains.
+	Variadic interface{}
+
+	// InputAt sets only some of the arguments of the call, by their
+	// position among the parameters of the method, the context being at 0,
+	// while the other ones match anything. It can not be used along with
+	// Input.
+	InputAt map[int]interface{}
+
+	// InputNamed sets only some of the arguments of the call, by the names
+	// of the parameters in the mocked interface, while the other ones match
+	// anything. It can be used along with InputAt, but not with Input.
+	InputNamed map[string]interface{}
+
 	// Return points to the successful return value of the call. It can be
 	// omitted when an error is desired.
 	Return interface{}
@@ -56,6 +83,35 @@ type MockOptions struct {
 	// The return values from this function are returned by the mocked
 	// function.
 	DoAndReturn interface{}
+
+	// IgnoreFields lists the fields, by name or by path such as
+	// "Meta.CreatedAt", that are not compared when matching the Input
+	// values, like timestamps or generated IDs.
+	IgnoreFields []string
+
+	// Panic makes the call panic with the given value instead of returning,
+	// to test how the code under test recovers from it. See AssertRecovered.
+	Panic interface{}
+
+	// Generator builds the values returned by each call from the result
+	// types of the method, instead of Return and Error. See FuzzGenerator.
+	Generator Generator
+
+	// InStates limits the expectation to the calls made while the mock
+	// client is in one of the states. See States.
+	InStates []string
+
+	// NextState moves the mock client to the state when the expectation is
+	// matched by a call that does not fail. See States.
+	NextState string
+
+	// Group adds the expectation to a group, which can be verified,
+	// cleared or disabled independently from the other expectations.
+	Group *Group
+
+	// Label is a human readable name for the expectation, shown in the
+	// report of unmet expectations when the test finishes.
+	Label string
 }
 
 // New returns a new mock service client that can be used to mock any service
@@ -70,9 +126,7 @@ func New[R any, T ServiceClient[R]](
 	t *testing.T,
 	fn FnNewClientService[T],
 ) *MockServiceClient[R, T] {
-	return &MockServiceClient[R, T]{
-		ServiceClient: fn(gomock.NewController(t)),
-	}
+	return NewWithCtrl[R](gomock.NewController(t), fn)
 }
 
 // NewWithCtrl returns a new mock service client that can be used to mock any service
@@ -87,8 +141,11 @@ func NewWithCtrl[R any, T ServiceClient[R]](
 	ctrl *gomock.Controller,
 	fn FnNewClientService[T],
 ) *MockServiceClient[R, T] {
+	client := fn(ctrl)
+
 	return &MockServiceClient[R, T]{
-		ServiceClient: fn(ctrl),
+		ServiceClient: client,
+		tracker:       newTracker(ctrl, client),
 	}
 }
 
@@ -104,9 +161,27 @@ func (m *MockServiceClient[R, T]) Client() T {
 
 // Mock is the way to build the desired "mocked" API by choosing which methods
 // are going to be called or not, specifying input arguments and return values.
+//
+// Every expectation remembers where it was set up, so that the ones that
+// are not met are reported along with the closest call received, if any,
+// when the test finishes.
 func (m *MockServiceClient[R, T]) Mock(opts *MockOptions) *MockServiceClient[R, T] {
-	if opts.Ctx == nil {
-		opts.Ctx = gomock.Any()
+	m.Expect(opts)
+	return m
+}
+
+// Expect sets up an expectation like Mock, but returns it instead, so that
+// the calls matching it can be followed. Waiting for it to be satisfied
+// makes tests of asynchronous code deterministic:
+//
+//	exp := mock.Expect(&MockOptions{Call: mock.Recorder().GetByString, Times: 1})
+//	go worker.Run(ctx)
+//	args, err := exp.Wait(time.Second)
+func (m *MockServiceClient[R, T]) Expect(opts *MockOptions) *Expectation {
+	// opts is not changed, so that it can be shared between goroutines.
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = gomock.Any()
 	}
 
 	callValue := reflect.ValueOf(opts.Call)
@@ -122,18 +197,92 @@ func (m *MockServiceClient[R, T]) Mock(opts *MockOptions) *MockServiceClient[R,
 		)
 	}
 
-	in := makeInputForCall(reflect.ValueOf(opts.Ctx), callValue, inputValue)
+	if opts.Panic != nil && opts.DoAndReturn != nil {
+		panic("Panic and DoAndReturn can not be used together")
+	}
+
+	if opts.Generator != nil && (opts.Panic != nil || opts.DoAndReturn != nil) {
+		panic("Generator can not be used along with Panic or DoAndReturn")
+	}
+
+	if (len(opts.InStates) > 0 || opts.NextState != "") && m.tracker.states.Load() == nil {
+		panic("InStates and NextState require the states of the mock client, set up with States")
+	}
+
+	if opts.Input != nil && (len(opts.InputAt) > 0 || len(opts.InputNamed) > 0) {
+		panic("Input can not be used along with InputAt or InputNamed")
+	}
+
+	exp := m.tracker.newExpectation(opts)
+	// The method type is only known for the methods of the mock client, but
+	// it tells whether a slice input holds the arguments or is one of them.
+	mt, _ := m.tracker.methodType(m.tracker.recordedMethod(callValue))
+	partial := len(opts.InputAt) > 0 || len(opts.InputNamed) > 0
+
+	var in []reflect.Value
+	if partial {
+		in = partialInput(reflect.ValueOf(ctx), callValue, m.tracker, mt, opts)
+	} else {
+		in = makeInputForCall(reflect.ValueOf(ctx), callValue, inputValue, mt)
+	}
+
+	if opts.Variadic != nil && !partial {
+		if opts.Input == nil && callValue.Type().IsVariadic() {
+			// Without Input, the fixed arguments match anything, but the
+			// variadic ones are set by Variadic.
+			in = in[:len(in)-1]
+		}
+
+		in = variadicInput(in, callValue, opts.Variadic, mt)
+	}
+
+	for i := range in {
+		in[i] = exp.track(i, matchProtoMessage(in[i]))
+	}
+
 	out := callValue.Call(in)
 	c := out[0].Interface().(*gomock.Call)
-	setupReturnValues(c, opts)
+	exp.bind(c)
 
-	return m
+	// The states are moved when the calls are served, which only tracked
+	// expectations do.
+	if opts.NextState != "" && !exp.tracked() {
+		m.tracker.t.Fatalf("mocks: NextState can only be used with the methods of the mock client, not %s", c)
+	}
+
+	setupReturnValues(c, opts, exp)
+
+	if opts.Group != nil {
+		opts.Group.add(exp, c, opts)
+	}
+
+	exp.ready()
+
+	return exp
+}
+
+// Calls returns how many times the method has been called, across all of
+// its expectations.
+func (m *MockServiceClient[R, T]) Calls(method string) int {
+	return m.tracker.callsTo(method)
+}
+
+// WaitForCalls blocks until the method has been called at least n times,
+// across all of its expectations, returning an error if the timeout elapses
+// before that. It is useful when the calls are made by other goroutines.
+func (m *MockServiceClient[R, T]) WaitForCalls(method string, n int, timeout time.Duration) error {
+	if !m.tracker.wait(timeout, func() bool { return m.tracker.calls[method] >= n }) {
+		return fmt.Errorf("mocks: timed out after %v waiting for %d call(s) to %s, got %d", timeout, n, method, m.Calls(method))
+	}
+
+	return nil
 }
 
 func makeInputForCall(
 	ctx reflect.Value,
 	call reflect.Value,
 	callInput reflect.Value,
+	methodType reflect.Type,
 ) []reflect.Value {
 	if callInput == reflect.ValueOf(nil) {
 		var (
@@ -150,9 +299,10 @@ func makeInputForCall(
 		return input
 	}
 
-	if callInput.Type().Kind() == reflect.Slice || callInput.Type().Kind() == reflect.Array {
+	if isArguments(callInput.Type(), methodType) {
 		// Here we need to convert the slice to a list of values.
 		values := getValuesFromSliceOrArray(callInput)
+		checkInputCount(call.Type(), len(values))
 
 		// We need to add one to the length because the first value is the context.
 		input := make([]reflect.Value, len(values)+1)
@@ -167,6 +317,8 @@ func makeInputForCall(
 		return input
 	}
 
+	checkInputCount(call.Type(), 1)
+
 	numIn := call.Type().NumIn()
 	if call.Type().IsVariadic() {
 		numIn = numIn - 1
@@ -179,33 +331,104 @@ func makeInputForCall(
 	return input
 }
 
-func setupReturnValues(mockCall *gomock.Call, opts *MockOptions) {
-	if opts.DoAndReturn != nil {
-		mockCall.DoAndReturn(
-			opts.DoAndReturn,
-		)
+// checkInputCount panics if the recorder of type ct can not receive n
+// arguments after the context.
+func checkInputCount(ct reflect.Type, n int) {
+	fixed := ct.NumIn() - 1
+	if ct.IsVariadic() {
+		fixed--
+	}
+
+	switch {
+	case ct.IsVariadic() && n < fixed:
+		panic(fmt.Sprintf("Input has %d argument(s) after the context, but the method receives at least %d", n, fixed))
+	case !ct.IsVariadic() && n != fixed:
+		panic(fmt.Sprintf("Input has %d argument(s) after the context, but the method receives %d", n, fixed))
+	}
+}
 
+func setupReturnValues(mockCall *gomock.Call, opts *MockOptions, exp *Expectation) {
+	if opts.Panic != nil {
+		if !exp.tracked() {
+			panic("Panic can only be used with the methods of the mock client")
+		}
+
+		exp.respond(mockCall, exp.panicWith(opts.Panic))
 		setupTimes(mockCall, opts)
 		return
 	}
 
-	if opts.SingleErrorReturned {
-		mockCall.Return(
-			opts.Error,
-		)
+	if opts.Generator != nil {
+		if !exp.tracked() {
+			panic("Generator can only be used with the methods of the mock client")
+		}
 
+		exp.respond(mockCall, generatedResults(exp.methodType, opts.Generator))
 		setupTimes(mockCall, opts)
 		return
 	}
 
-	rets := append(startReturnValues(opts), opts.Error)
+	if opts.DoAndReturn != nil {
+		if exp.tracked() {
+			exp.respond(mockCall, doAndReturnFor(exp.methodType, reflect.ValueOf(opts.DoAndReturn)))
+		} else {
+			mockCall.DoAndReturn(
+				opts.DoAndReturn,
+			)
+		}
+
+		setupTimes(mockCall, opts)
+		return
+	}
+
+	rets := []interface{}{opts.Error}
+	if !opts.SingleErrorReturned {
+		rets = append(startReturnValues(opts, exp.methodType), opts.Error)
+	}
+
+	// Return checks and converts the values to the method result types,
+	// which are then returned by the expectation.
 	mockCall.Return(
 		rets...,
 	)
 
+	if exp.tracked() {
+		exp.returns = rets
+		exp.respond(mockCall, func([]reflect.Value) []interface{} {
+			return rets
+		})
+	}
+
 	setupTimes(mockCall, opts)
 }
 
+// doAndReturnFor adapts a DoAndReturn function to be called with the
+// arguments received by the mocked method.
+func doAndReturnFor(mt reflect.Type, fn reflect.Value) func([]reflect.Value) []interface{} {
+	ft := fn.Type()
+	if ft.NumIn() != mt.NumIn() || ft.NumOut() != mt.NumOut() {
+		panic(
+			fmt.Sprintf("DoAndReturn must have the same signature as the mocked method, expected %v got %v", mt, ft),
+		)
+	}
+
+	return func(args []reflect.Value) []interface{} {
+		var out []reflect.Value
+		if ft.IsVariadic() {
+			out = fn.CallSlice(args)
+		} else {
+			out = fn.Call(args)
+		}
+
+		rets := make([]interface{}, len(out))
+		for i, v := range out {
+			rets[i] = v.Interface()
+		}
+
+		return rets
+	}
+}
+
 func setupTimes(mockCall *gomock.Call, opts *MockOptions) {
 	if opts.AnyTimes {
 		mockCall.AnyTimes()
@@ -215,12 +438,71 @@ func setupTimes(mockCall *gomock.Call, opts *MockOptions) {
 	mockCall.Times(opts.Times)
 }
 
-func startReturnValues(opts *MockOptions) []interface{} {
-	if opts.Return != nil && reflect.ValueOf(opts.Return).Type().Kind() == reflect.Slice {
-		return append([]interface{}{}, getValuesFromSliceOrArray(reflect.ValueOf(opts.Return)))
+// startReturnValues returns the values returned before the error. A slice
+// holds several of them, unless it is the first result of the method, such
+// as a []T returned by a generic method.
+func startReturnValues(opts *MockOptions, methodType reflect.Type) []interface{} {
+	if opts.Return == nil {
+		return []interface{}{nil}
+	}
+
+	v := reflect.ValueOf(opts.Return)
+	spread := v.Type() == reflect.TypeOf([]interface{}{})
+	if methodType != nil && methodType.NumOut() > 0 {
+		spread = v.Kind() == reflect.Slice && !v.Type().AssignableTo(methodType.Out(0))
+	}
+
+	if !spread {
+		return []interface{}{opts.Return}
+	}
+
+	values := getValuesFromSliceOrArray(v)
+	rets := make([]interface{}, len(values))
+	for i, value := range values {
+		rets[i] = value.Interface()
+	}
+
+	return rets
+}
+
+// isArguments tells if an input of type typ holds the arguments of the
+// method, rather than being its only argument after the context, like the
+// []T received by a generic method. A slice is only passed whole when that
+// argument is itself a slice or an array, not an interface like any.
+func isArguments(typ reflect.Type, methodType reflect.Type) bool {
+	if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+		return false
+	}
+
+	if methodType == nil || methodType.NumIn() != 2 || methodType.IsVariadic() {
+		return true
+	}
+
+	in := methodType.In(1)
+	if in.Kind() != reflect.Slice && in.Kind() != reflect.Array {
+		return true
+	}
+
+	return !typ.AssignableTo(in)
+}
+
+// recordedMethod returns the name of the method recorded by call, a method
+// value of a generated recorder, or an empty string if it is not one.
+func recordedMethod(call reflect.Value) string {
+	fn := runtime.FuncForPC(call.Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	// Method values are named after their receiver type and method, i.e,
+	// "example/mock.(*MockStoreMockRecorder[...]).Get-fm".
+	name := fn.Name()
+	if !strings.HasSuffix(name, "-fm") {
+		return ""
 	}
 
-	return append([]interface{}{}, opts.Return)
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
 }
 
 func getValuesFromSliceOrArray(v reflect.Value) []reflect.Value {
@@ -473,3 +473,113 @@ func TestAny(t *testing.T) {
 		a.Nil(output)
 	})
 }
+
+func TestGenericStore(t *testing.T) {
+	t.Run("should mock a method returning the type parameter", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockStore[*example.Example],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().Get,
+			Input:  "1",
+			Times:  1,
+			Return: &example.Example{Id: "1"},
+		})
+
+		out, err := mock.Client().Get(ctx, "1")
+		a.NoError(err)
+		a.Equal("1", out.Id)
+	})
+
+	t.Run("should mock a method returning a slice of the type parameter", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockStore[string],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().List,
+			Times:  1,
+			Return: []string{"a", "b"},
+		})
+
+		out, err := mock.Client().List(ctx)
+		a.NoError(err)
+		a.Equal([]string{"a", "b"}, out)
+	})
+
+	t.Run("should mock a method receiving a slice of the type parameter", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockStore[int],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:                 ctx,
+			Call:                mock.Recorder().Save,
+			Input:               []int{1, 2},
+			Times:               1,
+			SingleErrorReturned: true,
+		})
+
+		a.NoError(mock.Client().Save(ctx, []int{1, 2}))
+	})
+
+	t.Run("should mock a variadic method of the type parameter", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockStore[int],
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:                 ctx,
+			Call:                mock.Recorder().Put,
+			Input:               []int{1, 2},
+			Times:               1,
+			SingleErrorReturned: true,
+			Error:               errors.New("mocks: store is full"),
+		})
+
+		a.EqualError(mock.Client().Put(ctx, 1, 2), "mocks: store is full")
+		a.Equal(1, mock.Calls("Put"))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("should spread the input of a method receiving any", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockPublisher,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:                 ctx,
+			Call:                mock.Recorder().Publish,
+			Times:               1,
+			Input:               []interface{}{"hello"},
+			SingleErrorReturned: true,
+		})
+
+		err := mock.Client().Publish(ctx, "hello")
+		a.NoError(err)
+	})
+}
@@ -0,0 +1,110 @@
+package mocks
+
+import (
+	"reflect"
+)
+
+// injectedPanic is a panic raised by a call matching an expectation set up
+// with MockOptions.Panic.
+type injectedPanic struct {
+	expectation *Expectation
+	value       interface{}
+}
+
+// panicWith returns the results of an expectation whose calls panic with
+// value.
+func (e *Expectation) panicWith(value interface{}) func([]reflect.Value) []interface{} {
+	return func([]reflect.Value) []interface{} {
+		e.tracker.mu.Lock()
+		e.tracker.panics = append(e.tracker.panics, injectedPanic{expectation: e, value: value})
+		e.tracker.mu.Unlock()
+
+		panic(value)
+	}
+}
+
+func (tr *tracker) panicsInjected() int {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return len(tr.panics)
+}
+
+// injectedPanic returns the injected panic whose value is r, if any.
+func (tr *tracker) injectedPanic(r interface{}) (injectedPanic, bool) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	for i := len(tr.panics) - 1; i >= 0; i-- {
+		if samePanicValue(tr.panics[i].value, r) {
+			return tr.panics[i], true
+		}
+	}
+
+	return injectedPanic{}, false
+}
+
+func samePanicValue(a, b interface{}) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	if reflect.TypeOf(a).Comparable() {
+		return a == b
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// AssertRecovered runs fn, which calls the code under test, and asserts that
+// it recovered from the panics injected through MockOptions.Panic. An
+// injected panic escaping fn fails the test instead of crashing the test
+// binary, while other panics are propagated. It also fails if no panic was
+// injected while fn ran. Only the panics raised in the goroutine running fn
+// can be caught.
+//
+// Example:
+//
+//	mock.Mock(&MockOptions{Call: mock.Recorder().GetByString, Times: 1, Panic: "boom"})
+//	mock.AssertRecovered(func() { _, err = handler.Handle(ctx) })
+func (m *MockServiceClient[R, T]) AssertRecovered(fn func()) (recovered bool) {
+	tr := m.tracker
+	tr.t.Helper()
+
+	before := tr.panicsInjected()
+	finished := false
+
+	defer func() {
+		if finished {
+			return
+		}
+
+		// A nil value means that fn called runtime.Goexit, like t.FailNow.
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		p, ok := tr.injectedPanic(r)
+		if !ok {
+			panic(r)
+		}
+
+		tr.t.Errorf(
+			"mocks: panic %v injected by %s set up at %s was not recovered",
+			r, p.expectation.name(), p.expectation.site,
+		)
+
+		recovered = false
+	}()
+
+	fn()
+	finished = true
+
+	if tr.panicsInjected() == before {
+		tr.t.Errorf("mocks: no panic was injected into %T while running the function", tr.client)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,138 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+// recoverer is a middleware that turns panics into errors.
+func recoverer(fn func() (string, error)) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered: %v", r)
+		}
+	}()
+
+	return fn()
+}
+
+func TestPanic(t *testing.T) {
+	t.Run("should assert that the injected panic was recovered", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:   ctx,
+			Call:  mock.Recorder().GetByString,
+			Input: "Mocked Input",
+			Times: 1,
+			Panic: "boom",
+		})
+
+		var err error
+		a.True(mock.AssertRecovered(func() {
+			_, err = recoverer(func() (string, error) {
+				return mock.Client().GetByString(ctx, "Mocked Input")
+			})
+		}))
+
+		a.EqualError(err, "recovered: boom")
+		a.Equal(1, mock.Calls("GetByString"))
+	})
+
+	t.Run("should fail when the injected panic is not recovered", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:   ctx,
+			Call:  mock.Recorder().GetByString,
+			Times: 1,
+			Panic: errors.New("boom"),
+			Label: "crash",
+		})
+
+		recovered := true
+		run(func() {
+			recovered = mock.AssertRecovered(func() {
+				_, _ = mock.Client().GetByString(ctx, "Mocked Input")
+			})
+		})
+
+		a.False(recovered)
+		failures := reporter.finish()
+		a.Contains(failures, `mocks: panic boom injected by GetByString "crash" set up at panic_test.go:`)
+		a.Contains(failures, "was not recovered")
+	})
+
+	t.Run("should fail when no panic is injected", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			Panic:    "boom",
+		})
+
+		a.False(mock.AssertRecovered(func() {}))
+		a.Contains(reporter.finish(), "mocks: no panic was injected into *mock_example.MockExampleMock")
+	})
+
+	t.Run("should propagate panics that were not injected", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("unrelated", func() {
+			mock.AssertRecovered(func() {
+				panic("unrelated")
+			})
+		})
+	})
+
+	t.Run("should panic when used along with DoAndReturn", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("Panic and DoAndReturn can not be used together", func() {
+			mock.Mock(&MockOptions{
+				Call:        mock.Recorder().GetByString,
+				Panic:       "boom",
+				DoAndReturn: func(context.Context, string) (string, error) { return "", nil },
+			})
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package mocks
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Property checks a property of the code under test against many sets of
+// random values, built by generators of growing sizes. When it fails, the
+// property is checked again with smaller sizes, to find the simplest values
+// that make it fail.
+//
+// Example:
+//
+//	mocks.Property{Iterations: 50}.Check(t, func(t *testing.T, gen *mocks.RandomGenerator) {
+//		mock := mocks.New(t, example_mock.NewMockExampleMock)
+//		mock.Mock(&mocks.MockOptions{
+//			Call:      mock.Recorder().WithStruct,
+//			AnyTimes:  true,
+//			Generator: gen,
+//		})
+//
+//		_, err := handler.Handle(ctx, mock.Client())
+//		require.NoError(t, err)
+//	})
+type Property struct {
+	// Seed is the seed of the first iteration, which is incremented for the
+	// next ones. If zero, a random seed is used.
+	Seed int64
+
+	// Iterations is the number of times the property is checked. If zero,
+	// it is checked 100 times.
+	Iterations int
+
+	// MaxSize is the size of the generator of the last iteration, the sizes
+	// growing evenly up to it. If zero, it is 32.
+	MaxSize int
+}
+
+// Check checks the property, with fn, in a subtest for each iteration. The
+// seed and size of the simplest failing values are reported, along with the
+// Property that reproduces them. It returns whether the property holds.
+func (p Property) Check(t *testing.T, fn func(t *testing.T, gen *RandomGenerator)) bool {
+	t.Helper()
+
+	seed, size, failed := p.search(func(seed int64, size int) bool {
+		return t.Run(fmt.Sprintf("seed=%d,size=%d", seed, size), func(t *testing.T) {
+			fn(t, NewRandomGenerator(seed).WithSize(size))
+		})
+	})
+
+	if failed {
+		t.Errorf(
+			"mocks: property failed with seed %d and size %d, reproduce it with Property{Seed: %d, Iterations: 1, MaxSize: %d}",
+			seed, size, seed, size,
+		)
+	}
+
+	return !failed
+}
+
+// search runs the iterations of the property until one fails, then shrinks
+// its size while it keeps failing, returning the seed and size of the last
+// failure.
+func (p Property) search(holds func(seed int64, size int) bool) (int64, int, bool) {
+	if p.Seed == 0 {
+		p.Seed = time.Now().UnixNano()
+	}
+
+	if p.Iterations <= 0 {
+		p.Iterations = 100
+	}
+
+	if p.MaxSize <= 0 {
+		p.MaxSize = 32
+	}
+
+	for i := 0; i < p.Iterations; i++ {
+		seed, size := p.Seed+int64(i), p.MaxSize*(i+1)/p.Iterations
+		if holds(seed, size) {
+			continue
+		}
+
+		for size > 0 && !holds(seed, size-1) {
+			size--
+		}
+
+		return seed, size, true
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestProperty(t *testing.T) {
+	t.Run("should shrink the size of the first failing iteration", func(t *testing.T) {
+		a := assert.New(t)
+
+		var runs [][2]int64
+		seed, size, failed := Property{Seed: 10, Iterations: 10, MaxSize: 20}.search(func(seed int64, size int) bool {
+			runs = append(runs, [2]int64{seed, int64(size)})
+			return size < 5
+		})
+
+		a.True(failed)
+		a.Equal(int64(12), seed)
+		a.Equal(5, size)
+		a.Equal([][2]int64{{10, 2}, {11, 4}, {12, 6}, {12, 5}, {12, 4}}, runs)
+	})
+
+	t.Run("should run every iteration when the property holds", func(t *testing.T) {
+		a := assert.New(t)
+
+		iterations := 0
+		_, _, failed := Property{Iterations: 5}.search(func(int64, int) bool {
+			iterations++
+			return true
+		})
+
+		a.False(failed)
+		a.Equal(5, iterations)
+	})
+
+	t.Run("should check properties against mocked responses", func(t *testing.T) {
+		ctx := context.TODO()
+
+		Property{Seed: 1, Iterations: 20}.Check(t, func(t *testing.T, gen *RandomGenerator) {
+			mock := New(
+				t,
+				example_mock.NewMockExampleMock,
+			)
+
+			mock.Mock(&MockOptions{
+				Ctx:       ctx,
+				Call:      mock.Recorder().WithStruct,
+				Times:     1,
+				Generator: gen,
+			})
+
+			out, err := mock.Client().WithStruct(ctx, &example.Example{})
+			assert.NoError(t, err)
+			if out != nil {
+				assert.LessOrEqual(t, len(out.Id), gen.Size())
+			}
+		})
+	})
+}
@@ -0,0 +1,151 @@
+package mocks
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+type tagged struct {
+	Id      string  `gen:"minlen=2,maxlen=4"`
+	Status  string  `gen:"oneof=active|inactive"`
+	Score   float64 `gen:"min=0,max=1"`
+	Level   uint8   `gen:"min=1,max=3"`
+	Codes   []int   `gen:"maxlen=2,oneof=200|404"`
+	Parent  *tagged `gen:"-"`
+	Owner   *string `gen:"required"`
+	Free    int
+	Entries []string
+}
+
+func TestRandomGenerator(t *testing.T) {
+	t.Run("should build the same values from the same seed", func(t *testing.T) {
+		a := assert.New(t)
+		typ := reflect.TypeOf(tagged{})
+
+		first := NewRandomGenerator(42).Generate(typ).Interface()
+		second := NewRandomGenerator(42).Generate(typ).Interface()
+		other := NewRandomGenerator(43).Generate(typ).Interface()
+
+		a.Equal(first, second)
+		a.NotEqual(first, other)
+	})
+
+	t.Run("should respect the tags and the size", func(t *testing.T) {
+		a := assert.New(t)
+		gen := NewRandomGenerator(7).WithSize(3)
+
+		for i := 0; i < 200; i++ {
+			v := Generate[tagged](gen)
+
+			a.GreaterOrEqual(len(v.Id), 2)
+			a.LessOrEqual(len(v.Id), 4)
+			a.Contains([]string{"active", "inactive"}, v.Status)
+			a.GreaterOrEqual(v.Score, 0.0)
+			a.Less(v.Score, 1.0)
+			a.Contains([]uint8{1, 2, 3}, v.Level)
+			a.LessOrEqual(len(v.Codes), 2)
+			for _, c := range v.Codes {
+				a.Contains([]int{200, 404}, c)
+			}
+			a.Nil(v.Parent)
+			a.NotNil(v.Owner)
+			a.LessOrEqual(len(*v.Owner), 3)
+			a.LessOrEqual(v.Free, 3)
+			a.GreaterOrEqual(v.Free, -3)
+			a.LessOrEqual(len(v.Entries), 3)
+		}
+	})
+
+	t.Run("should build empty values with size zero", func(t *testing.T) {
+		a := assert.New(t)
+		gen := NewRandomGenerator(7).WithSize(0)
+
+		a.Equal(0, Generate[int](gen))
+		a.Empty(Generate[string](gen))
+		a.Empty(Generate[[]int](gen))
+		a.Equal(0, gen.Size())
+		a.Equal(int64(7), gen.Seed())
+	})
+
+	t.Run("should build errors according to the error rate", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.NoError(Generate[error](NewRandomGenerator(1)))
+		a.ErrorIs(Generate[error](NewRandomGenerator(1).WithErrorRate(1)), ErrGenerated)
+	})
+
+	t.Run("should build registered types with their generator", func(t *testing.T) {
+		a := assert.New(t)
+		gen := NewRandomGenerator(3).WithSize(5)
+
+		RegisterGenerator(gen, func(r *rand.Rand, size int) example.Example {
+			return example.Example{Id: "ex-" + strconv.Itoa(r.Intn(size+1)), Value: "valid"}
+		})
+
+		for i := 0; i < 20; i++ {
+			v := Generate[[]example.Example](gen)
+			for _, e := range v {
+				a.Regexp(`^ex-[0-5]$`, e.Id)
+				a.Equal("valid", e.Value)
+			}
+		}
+	})
+
+	t.Run("should be usable as the return value of a call", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		gen := NewRandomGenerator(11)
+
+		RegisterGenerator(gen, func(r *rand.Rand, size int) example.Example {
+			return example.Example{Id: "generated"}
+		})
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().WithStruct,
+			Times:  1,
+			Return: Generate[*example.Example](gen),
+		}).Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().GetByInt,
+			Times:     1,
+			Generator: gen,
+		})
+
+		out, err := mock.Client().WithStruct(ctx, &example.Example{})
+		a.NoError(err)
+		if out != nil {
+			a.Equal("generated", out.Id)
+		}
+
+		i, err := mock.Client().GetByInt(ctx, 1)
+		a.NoError(err)
+		a.LessOrEqual(i, 8)
+	})
+
+	t.Run("should panic on invalid tags", func(t *testing.T) {
+		a := assert.New(t)
+
+		type invalid struct {
+			Count int `gen:"min=one"`
+		}
+
+		a.PanicsWithValue(
+			`invalid gen tag option "min=one" of mocks.invalid.Count: strconv.ParseFloat: parsing "one": invalid syntax`,
+			func() { Generate[invalid](NewRandomGenerator(1)) },
+		)
+	})
+}
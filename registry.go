@@ -0,0 +1,178 @@
+package mocks
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Registry creates the mock clients of a test from a single controller, so
+// that tests using many of them do not need to create and verify each one.
+// Mock clients are created once per type and then reused.
+//
+// Example:
+//
+//	reg := mocks.NewRegistry(t)
+//	users := mocks.Get(reg, users_mock.NewMockUsersClient)
+//	orders := mocks.Get(reg, orders_mock.NewMockOrdersClient)
+type Registry struct {
+	ctrl *gomock.Controller
+
+	mu           sync.Mutex
+	constructors map[reflect.Type]func() *registered
+	provided     []reflect.Type
+	clients      map[reflect.Type]*registered
+	order        []reflect.Type
+}
+
+// registered is a mock client created by a registry.
+type registered struct {
+	client  interface{}
+	mock    interface{}
+	tracker *tracker
+}
+
+// NewRegistry returns a new registry whose mock clients are verified when
+// the test finishes.
+func NewRegistry(t *testing.T) *Registry {
+	return NewRegistryWithCtrl(gomock.NewController(t))
+}
+
+// NewRegistryWithCtrl returns a new registry creating its mock clients from
+// ctrl.
+func NewRegistryWithCtrl(ctrl *gomock.Controller) *Registry {
+	return &Registry{
+		ctrl:         ctrl,
+		constructors: make(map[reflect.Type]func() *registered),
+		clients:      make(map[reflect.Type]*registered),
+	}
+}
+
+// Controller returns the controller shared by the mock clients.
+func (r *Registry) Controller() *gomock.Controller {
+	return r.ctrl
+}
+
+// Get returns the mock client of type T from the registry, creating it with
+// fn the first time.
+//
+// Example:
+//
+//	mock := mocks.Get(reg, example_mock.NewMockExampleMock)
+func Get[R any, T ServiceClient[R]](reg *Registry, fn FnNewClientService[T]) *MockServiceClient[R, T] {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	typ := register[R](reg, fn)
+	return reg.create(typ).mock.(*MockServiceClient[R, T])
+}
+
+// Register adds the constructor of the mock client of type T to the
+// registry, without creating it. It is created when first needed by Get or
+// Inject.
+//
+// Example:
+//
+//	mocks.Register(reg, example_mock.NewMockExampleMock)
+func Register[R any, T ServiceClient[R]](reg *Registry, fn FnNewClientService[T]) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	register[R](reg, fn)
+}
+
+// register adds the constructor of the mock client of type T, unless one
+// was already added, returning T. The registry must be locked.
+func register[R any, T ServiceClient[R]](reg *Registry, fn FnNewClientService[T]) reflect.Type {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if _, ok := reg.constructors[typ]; ok {
+		return typ
+	}
+
+	reg.constructors[typ] = func() *registered {
+		mock := NewWithCtrl[R](reg.ctrl, fn)
+		return &registered{
+			client:  mock.Client(),
+			mock:    mock,
+			tracker: mock.tracker,
+		}
+	}
+	reg.provided = append(reg.provided, typ)
+
+	return typ
+}
+
+// create returns the mock client of type typ, creating it the first time.
+// The registry must be locked.
+func (r *Registry) create(typ reflect.Type) *registered {
+	if c, ok := r.clients[typ]; ok {
+		return c
+	}
+
+	c := r.constructors[typ]()
+	r.clients[typ] = c
+	r.order = append(r.order, typ)
+
+	return c
+}
+
+// Lookup returns the mock client of type T from the registry, if it was
+// already created.
+//
+// Example:
+//
+//	mock, ok := mocks.Lookup[example_mock.MockExampleMockMockRecorder, *example_mock.MockExampleMock](reg)
+func Lookup[R any, T ServiceClient[R]](reg *Registry) (*MockServiceClient[R, T], bool) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	c, ok := reg.clients[reflect.TypeOf((*T)(nil)).Elem()]
+	if !ok {
+		return nil, false
+	}
+
+	return c.mock.(*MockServiceClient[R, T]), true
+}
+
+// Clients returns the clients of every mock created by the registry, in the
+// order they were created.
+func (r *Registry) Clients() []interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := make([]interface{}, len(r.order))
+	for i, typ := range r.order {
+		clients[i] = r.clients[typ].client
+	}
+
+	return clients
+}
+
+// Verify returns an error describing the expectations of all mock clients
+// that were not met so far, or nil if all of them were. The expectations
+// are also verified when the test finishes.
+func (r *Registry) Verify() error {
+	r.mu.Lock()
+	trackers := make([]*tracker, len(r.order))
+	for i, typ := range r.order {
+		trackers[i] = r.clients[typ].tracker
+	}
+	r.mu.Unlock()
+
+	var unmet []string
+	for _, tr := range trackers {
+		if u := tr.unmet(); u != "" {
+			unmet = append(unmet, u)
+		}
+	}
+
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(unmet, "\n"))
+}
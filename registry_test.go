@@ -0,0 +1,107 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+	greeter_mock "github.com/somatech1/mocks/internal/greeter/mock"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("should create each mock client once", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		example := Get(reg, example_mock.NewMockExampleMock)
+		greeter := Get(reg, greeter_mock.NewMockGreeterClient)
+
+		a.Same(example, Get(reg, example_mock.NewMockExampleMock))
+		a.Same(greeter, Get(reg, greeter_mock.NewMockGreeterClient))
+		a.Equal([]interface{}{example.Client(), greeter.Client()}, reg.Clients())
+	})
+
+	t.Run("should look mock clients up by type", func(t *testing.T) {
+		a := assert.New(t)
+		reg := NewRegistry(t)
+
+		_, ok := Lookup[example_mock.MockExampleMockMockRecorder, *example_mock.MockExampleMock](reg)
+		a.False(ok)
+
+		example := Get(reg, example_mock.NewMockExampleMock)
+
+		found, ok := Lookup[example_mock.MockExampleMockMockRecorder, *example_mock.MockExampleMock](reg)
+		a.True(ok)
+		a.Same(example, found)
+	})
+
+	t.Run("should verify all mock clients together", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+		reg := NewRegistryWithCtrl(gomock.NewController(reporter))
+
+		example := Get(reg, example_mock.NewMockExampleMock)
+		greeter := Get(reg, greeter_mock.NewMockGreeterClient)
+
+		example.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   example.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		greeter.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   greeter.Recorder().SayHello,
+			Times:  1,
+			Return: &wrapperspb.StringValue{Value: "Hello"},
+		})
+
+		err := reg.Verify()
+		a.ErrorContains(err, "mocks: unmet expectations for *mock_example.MockExampleMock:")
+		a.ErrorContains(err, "mocks: unmet expectations for *mock_greeter.MockGreeterClient:")
+
+		_, _ = example.Client().GetByString(ctx, "Mocked Input")
+
+		err = reg.Verify()
+		a.NotContains(err.Error(), "MockExampleMock")
+		a.ErrorContains(err, "SayHello: expected 1 call(s), got 0")
+
+		_, _ = greeter.Client().SayHello(ctx, &wrapperspb.StringValue{Value: "World"})
+
+		a.NoError(reg.Verify())
+		a.Empty(reporter.finish())
+	})
+
+	t.Run("should share the controller between mock clients", func(t *testing.T) {
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+		reg := NewRegistryWithCtrl(gomock.NewController(reporter))
+
+		example := Get(reg, example_mock.NewMockExampleMock)
+		greeter := Get(reg, greeter_mock.NewMockGreeterClient)
+
+		example.Mock(&MockOptions{
+			Call:   example.Recorder().GetByString,
+			Times:  1,
+			Return: "Mocked Output",
+		})
+
+		greeter.Mock(&MockOptions{
+			Call:   greeter.Recorder().SayHello,
+			Times:  1,
+			Return: &wrapperspb.StringValue{Value: "Hello"},
+		})
+
+		run(reg.Controller().Finish)
+
+		failures := reporter.finish()
+		a.Contains(failures, "MockExampleMock.GetByString")
+		a.Contains(failures, "MockGreeterClient.SayHello")
+	})
+}
@@ -0,0 +1,223 @@
+package mocks
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// cleanuper is implemented by test reporters able to run functions when
+// the test finishes, like *testing.T.
+type cleanuper interface {
+	Cleanup(func())
+}
+
+// tracker keeps every expectation set up through a mock service client,
+// along with the calls that were made to it, so that a consolidated report
+// can be produced when the test finishes.
+type tracker struct {
+	t      gomock.TestHelper
+	client interface{}
+
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        map[string]int
+	total        int
+	layers       []func(*invocation)
+	panics       []injectedPanic
+	log          []*invocation
+
+	// states is set once by States, and read by the matchers without
+	// holding mu.
+	states atomic.Pointer[StateMachine]
+
+	// coverage records the methods mocked and called across the tests.
+	coverage *coverage
+
+	// changed is closed, and replaced, every time a call is made.
+	changed chan struct{}
+}
+
+func newTracker(ctrl *gomock.Controller, client interface{}) *tracker {
+	tr := &tracker{
+		t:        ctrl.T,
+		client:   client,
+		calls:    make(map[string]int),
+		changed:  make(chan struct{}),
+		coverage: suiteCoverage,
+	}
+
+	// Cleanup functions run in the reverse order of their registration, so
+	// the report is shown right before the controller verification, which
+	// was registered when it was created.
+	if c, ok := ctrl.T.(cleanuper); ok {
+		c.Cleanup(tr.report)
+	}
+
+	tr.coverage.register(client)
+
+	return tr
+}
+
+// use adds a layer that can change the results of every call made to the
+// mock client.
+func (tr *tracker) use(layer func(*invocation)) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.layers = append(tr.layers, layer)
+}
+
+func (tr *tracker) perturb(inv *invocation) {
+	tr.mu.Lock()
+	layers := append([]func(*invocation){}, tr.layers...)
+	tr.mu.Unlock()
+
+	for _, layer := range layers {
+		layer(inv)
+	}
+}
+
+func (tr *tracker) totalCalls() int {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return tr.total
+}
+
+func (tr *tracker) callsTo(method string) int {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return tr.calls[method]
+}
+
+// wait blocks until done, which is called with the tracker locked, returns
+// true after a call is made, or until the timeout elapses.
+func (tr *tracker) wait(timeout time.Duration, done func() bool) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		tr.mu.Lock()
+		ok := done()
+		changed := tr.changed
+		tr.mu.Unlock()
+
+		if ok {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			tr.mu.Lock()
+			defer tr.mu.Unlock()
+
+			return done()
+		}
+	}
+}
+
+// report shows every expectation that was not met, grouped by method.
+func (tr *tracker) report() {
+	tr.t.Helper()
+
+	if unmet := tr.unmet(); unmet != "" {
+		tr.t.Errorf("%s", unmet)
+	}
+}
+
+// unmet describes every expectation that was not met so far, grouped by
+// method, or returns an empty string if all of them were.
+func (tr *tracker) unmet() string {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	unmet := make(map[string][]*Expectation)
+	var methods []string
+	for _, e := range tr.expectations {
+		if !e.unmet() {
+			continue
+		}
+
+		if _, ok := unmet[e.method]; !ok {
+			methods = append(methods, e.method)
+		}
+
+		unmet[e.method] = append(unmet[e.method], e)
+	}
+
+	if len(methods) == 0 {
+		return ""
+	}
+
+	sort.Strings(methods)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "mocks: unmet expectations for %T:\n", tr.client)
+	for _, method := range methods {
+		expected := 0
+		for _, e := range tr.expectations {
+			if e.method == method && e.required() {
+				expected += e.minCalls
+			}
+		}
+
+		fmt.Fprintf(&b, "\n%s: expected %d call(s), got %d\n", method, expected, tr.calls[method])
+		for _, e := range unmet[method] {
+			e.describe(&b)
+		}
+	}
+
+	return b.String()
+}
+
+func (e *Expectation) describe(b *strings.Builder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	fmt.Fprintf(b, "  - %s set up at %s: expected %d call(s), got %d\n", e.name(), e.site, e.minCalls, e.calls)
+	if e.closest == nil {
+		return
+	}
+
+	fmt.Fprintf(b, "    closest call differs at %s:\n", e.tracker.argumentName(e.method, e.closest.index))
+	fmt.Fprintf(b, "      want: %v\n", e.closest.want.Matcher)
+	fmt.Fprintf(b, "      got:  %v (%T)\n", e.closest.got, e.closest.got)
+
+	if e.closest.want.hasValue {
+		for _, d := range diffLines(e.closest.want.value, e.closest.got, e.ignore) {
+			fmt.Fprintf(b, "        %s\n", d)
+		}
+	}
+}
+
+// packageDir is the directory of this package, whose non test files are
+// skipped when looking for the caller of the package.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerSite returns the file and line of the first caller outside this
+// package.
+func callerSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		inPackage := filepath.Dir(frame.File) == packageDir && !strings.HasSuffix(frame.File, "_test.go")
+		if !inPackage || !more {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/somatech1/mocks/internal/example"
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+// fakeReporter is a test reporter that records failures instead of failing
+// the test, so that the messages produced by the package can be checked.
+type fakeReporter struct {
+	mu       sync.Mutex
+	failures []string
+	cleanups []func()
+}
+
+func (f *fakeReporter) Errorf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeReporter) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (f *fakeReporter) Helper() {}
+
+func (f *fakeReporter) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+// finish runs the cleanup functions like the testing package does when a
+// test finishes, returning all recorded failures.
+func (f *fakeReporter) finish() string {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		run(f.cleanups[i])
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return strings.Join(f.failures, "\n")
+}
+
+// run calls fn in its own goroutine, which may be stopped by Fatalf.
+func run(fn func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	<-done
+}
+
+func TestReport(t *testing.T) {
+	t.Run("should report unmet expectation with its label and setup site", func(t *testing.T) {
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().GetByString,
+			Times:  1,
+			Input:  "Hello World",
+			Return: "Mocked Output",
+			Label:  "fetch greeting",
+		})
+
+		report := reporter.finish()
+
+		a.Contains(report, "unmet expectations for *mock_example.MockExampleMock")
+		a.Contains(report, "GetByString: expected 1 call(s), got 0")
+		a.Contains(report, `GetByString "fetch greeting" set up at report_test.go:`)
+	})
+
+	t.Run("should report the closest call with its different fields", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().WithStruct,
+			Times:  1,
+			Input:  []interface{}{&example.Example{Id: "1", Value: "Hello World"}},
+			Return: &example.Example{Id: "1", Value: "Mocked Output"},
+		})
+
+		run(func() {
+			_, _ = mock.Client().WithStruct(ctx, &example.Example{Id: "1", Value: "Hello"})
+		})
+
+		report := reporter.finish()
+
+		a.Contains(report, "WithStruct: expected 1 call(s), got 0")
+		a.Contains(report, "closest call differs at argument 1")
+		a.Contains(report, `Value: want "Hello World", got "Hello"`)
+		a.NotContains(report, "Id: want")
+	})
+
+	t.Run("should not report met expectations", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Call:   mock.Recorder().GetByInt,
+			Times:  1,
+			Input:  42,
+			Return: 100,
+		}).Mock(&MockOptions{
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			Return:   "Mocked Output",
+		})
+
+		output, err := mock.Client().GetByInt(ctx, 42)
+
+		a.NoError(err)
+		a.Equal(100, output)
+		a.Empty(reporter.finish())
+	})
+}
@@ -0,0 +1,158 @@
+package mocks
+
+import (
+	"fmt"
+	"sync"
+)
+
+// StateMachine holds the state of a mocked service, like the lifecycle of a
+// subscription, on which its expectations depend. Expectations set up with
+// MockOptions.InStates only match calls made in those states, and the ones
+// set up with MockOptions.NextState move the service to a new state when
+// they are matched. Moving to a state through a transition that was not
+// allowed fails the test.
+//
+// Example:
+//
+//	mock.States("pending").
+//		Allow("pending", "active", "cancelled").
+//		Allow("active", "cancelled")
+//
+//	mock.Mock(&MockOptions{
+//		Call:      mock.Recorder().Activate,
+//		AnyTimes:  true,
+//		InStates:  []string{"pending"},
+//		NextState: "active",
+//	})
+type StateMachine struct {
+	tracker *tracker
+
+	mu          sync.Mutex
+	current     string
+	transitions map[string]map[string]bool
+	history     []string
+}
+
+// States sets the initial state of the mock client, returning its state
+// machine, to which the allowed transitions are added. Calling it again
+// returns the same state machine, moved to the initial state.
+func (m *MockServiceClient[R, T]) States(initial string) *StateMachine {
+	tr := m.tracker
+
+	tr.states.CompareAndSwap(nil, &StateMachine{
+		tracker:     tr,
+		transitions: make(map[string]map[string]bool),
+	})
+
+	s := tr.states.Load()
+
+	s.Set(initial)
+	return s
+}
+
+// Allow allows the transitions from a state to the others.
+func (s *StateMachine) Allow(from string, to ...string) *StateMachine {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.transitions[from] == nil {
+		s.transitions[from] = make(map[string]bool)
+	}
+
+	for _, state := range to {
+		s.transitions[from][state] = true
+	}
+
+	return s
+}
+
+// Set moves to the state without checking the transition, which is useful
+// to set up tests starting in the middle of a lifecycle.
+func (s *StateMachine) Set(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.current = state
+	s.history = append(s.history, state)
+}
+
+// Current returns the current state.
+func (s *StateMachine) Current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current
+}
+
+// History returns every state taken so far, in order, starting with the
+// initial one.
+func (s *StateMachine) History() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.history...)
+}
+
+// in tells if the current state is one of states.
+func (s *StateMachine) in(states []string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.isIn(states)
+}
+
+// isIn is like in, with s locked.
+func (s *StateMachine) isIn(states []string) bool {
+	for _, state := range states {
+		if state == s.current {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transition moves to the next state of an expectation that was matched,
+// failing the test if the transition is not allowed. Staying in the same
+// state is always allowed.
+//
+// Calls are matched before their expectations run, so concurrent calls may
+// be matched in the same state. The states of the expectation are checked
+// again along with the move, under the same lock, so that only the first of
+// them moves the state, while the others fail the test.
+func (s *StateMachine) transition(e *Expectation) {
+	s.tracker.t.Helper()
+
+	s.mu.Lock()
+	from, to := s.current, e.nextState
+	matched := len(e.inStates) == 0 || s.isIn(e.inStates)
+	allowed := matched && (from == to || s.transitions[from][to])
+	if allowed {
+		s.current = to
+		s.history = append(s.history, to)
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !matched:
+		s.tracker.t.Errorf(
+			"mocks: %s set up at %s can not move to state %q, another call moved from states %q to %q first",
+			e.name(), e.site, to, e.inStates, from,
+		)
+	case !allowed:
+		s.tracker.t.Errorf(
+			"mocks: illegal transition from state %q to %q by %s set up at %s",
+			from, to, e.name(), e.site,
+		)
+	}
+}
+
+// inactive describes why an expectation can not match calls in the current
+// state, or returns an empty string if it can.
+func (s *StateMachine) inactive(e *Expectation) string {
+	if len(e.inStates) == 0 || s.in(e.inStates) {
+		return ""
+	}
+
+	return fmt.Sprintf("only in states %q, current state is %q", e.inStates, s.Current())
+}
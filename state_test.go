@@ -0,0 +1,253 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+	greeter_mock "github.com/somatech1/mocks/internal/greeter/mock"
+)
+
+func TestStates(t *testing.T) {
+	t.Run("should only match the expectations of the current state", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		states := mock.States("pending").
+			Allow("pending", "active").
+			Allow("active", "cancelled")
+
+		mock.Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().GetByString,
+			Input:     "status",
+			AnyTimes:  true,
+			Return:    "pending",
+			InStates:  []string{"pending"},
+			NextState: "active",
+		}).Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().GetByString,
+			Input:     "status",
+			AnyTimes:  true,
+			Return:    "active",
+			InStates:  []string{"active"},
+			NextState: "cancelled",
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			Input:    "status",
+			AnyTimes: true,
+			Return:   "cancelled",
+			InStates: []string{"cancelled"},
+		})
+
+		var got []string
+		for i := 0; i < 4; i++ {
+			out, err := mock.Client().GetByString(ctx, "status")
+			a.NoError(err)
+			got = append(got, out)
+		}
+
+		a.Equal([]string{"pending", "active", "cancelled", "cancelled"}, got)
+		a.Equal("cancelled", states.Current())
+		a.Equal([]string{"pending", "active", "cancelled"}, states.History())
+	})
+
+	t.Run("should not change the state on failed calls", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		states := mock.States("pending").Allow("pending", "active")
+
+		mock.Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().GetByInt,
+			Times:     1,
+			Return:    0,
+			Error:     errors.New("mocks: activation failed"),
+			NextState: "active",
+		})
+
+		_, err := mock.Client().GetByInt(ctx, 1)
+		a.Error(err)
+		a.Equal("pending", states.Current())
+	})
+
+	t.Run("should report illegal transitions", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		states := mock.States("cancelled").Allow("pending", "active")
+
+		mock.Mock(&MockOptions{
+			Ctx:       ctx,
+			Call:      mock.Recorder().GetByString,
+			Times:     1,
+			Return:    "active",
+			NextState: "active",
+		})
+
+		run(func() {
+			_, _ = mock.Client().GetByString(ctx, "activate")
+		})
+
+		a.Equal("cancelled", states.Current())
+		failures := reporter.finish()
+		a.Contains(failures, `mocks: illegal transition from state "cancelled" to "active" by GetByString set up at state_test.go:`)
+	})
+
+	t.Run("should move the state once when concurrent calls match in the same state", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		states := mock.States("pending").Allow("pending", "active")
+
+		// Both calls are matched in the pending state before either of them
+		// moves it.
+		var matched sync.WaitGroup
+		matched.Add(2)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			DoAndReturn: func(context.Context, string) (string, error) {
+				matched.Done()
+				matched.Wait()
+				return "active", nil
+			},
+			InStates:  []string{"pending"},
+			NextState: "active",
+		})
+
+		var calls sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			calls.Add(1)
+			go func() {
+				defer calls.Done()
+				run(func() {
+					_, _ = mock.Client().GetByString(ctx, "activate")
+				})
+			}()
+		}
+
+		calls.Wait()
+
+		a.Equal([]string{"pending", "active"}, states.History())
+		failures := reporter.finish()
+		a.Contains(failures, `mocks: GetByString set up at state_test.go:`)
+		a.Contains(failures, `can not move to state "active", another call moved from states ["pending"] to "active" first`)
+	})
+
+	t.Run("should fail when the next state is set for another mock client", func(t *testing.T) {
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+		ctrl := gomock.NewController(reporter)
+
+		mock := NewWithCtrl(ctrl, example_mock.NewMockExampleMock)
+		other := NewWithCtrl(ctrl, greeter_mock.NewMockGreeterClient)
+
+		mock.States("pending")
+
+		run(func() {
+			mock.Mock(&MockOptions{
+				Call:      other.Recorder().SayHello,
+				AnyTimes:  true,
+				NextState: "active",
+			})
+		})
+
+		a.Contains(reporter.finish(), "mocks: NextState can only be used with the methods of the mock client, not *mock_greeter.MockGreeterClient.SayHello(")
+	})
+
+	t.Run("should explain why calls outside of the states are unexpected", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.States("pending")
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetByString,
+			AnyTimes: true,
+			Return:   "refunded",
+			InStates: []string{"cancelled"},
+		})
+
+		run(func() {
+			_, _ = mock.Client().GetByString(ctx, "refund")
+		})
+
+		failures := reporter.finish()
+		a.Contains(failures, "Unexpected call")
+		a.Contains(failures, `only in states ["cancelled"], current state is "pending"`)
+	})
+
+	t.Run("should let tests set the state directly", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		states := mock.States("pending")
+		states.Set("active")
+
+		a.Equal("active", states.Current())
+		a.Same(states, mock.States("pending"))
+		a.Equal([]string{"pending", "active", "pending"}, states.History())
+	})
+
+	t.Run("should panic when states are used before being set up", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("InStates and NextState require the states of the mock client, set up with States", func() {
+			mock.Mock(&MockOptions{
+				Call:     mock.Recorder().GetByString,
+				AnyTimes: true,
+				Return:   "x",
+				InStates: []string{"active"},
+			})
+		})
+	})
+}
@@ -0,0 +1,242 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrStreamClosed is returned when a message is sent through a Stream after
+// its sending side has been closed.
+var ErrStreamClosed = errors.New("mocks: send on closed stream")
+
+// Stream is a fake gRPC client stream that can be used as the Return value
+// of streaming recorder methods. It implements grpc.ServerStreamingClient,
+// grpc.ClientStreamingClient and grpc.BidiStreamingClient, as well as the
+// equivalent interfaces generated by older protoc-gen-go-grpc versions.
+//
+// Messages received by the code under test are programmed as an ordered
+// script, while messages sent by it are recorded and can be retrieved
+// with Sent.
+//
+// Example:
+//
+//	stream := NewStream[pb.Request, pb.Response](ctx).
+//		Respond(&pb.Response{Id: "1"}, &pb.Response{Id: "2"}).
+//		RespondEOF()
+type Stream[Req any, Res any] struct {
+	ctx     context.Context
+	header  metadata.MD
+	trailer metadata.MD
+
+	mu         sync.Mutex
+	script     []streamStep
+	next       int
+	sent       []*Req
+	sendClosed bool
+}
+
+// streamStep is a single scripted result of a Recv call.
+type streamStep struct {
+	message interface{}
+	err     error
+}
+
+var (
+	_ grpc.ServerStreamingClient[struct{}]           = (*Stream[struct{}, struct{}])(nil)
+	_ grpc.ClientStreamingClient[struct{}, struct{}] = (*Stream[struct{}, struct{}])(nil)
+	_ grpc.BidiStreamingClient[struct{}, struct{}]   = (*Stream[struct{}, struct{}])(nil)
+)
+
+// NewStream returns a new fake stream with an empty script. Its Context
+// method returns ctx, or context.Background if ctx is nil.
+func NewStream[Req any, Res any](ctx context.Context) *Stream[Req, Res] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return &Stream[Req, Res]{
+		ctx: ctx,
+	}
+}
+
+// Respond appends messages to the script, which will be returned, in
+// order, by the following Recv calls.
+func (s *Stream[Req, Res]) Respond(messages ...*Res) *Stream[Req, Res] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range messages {
+		s.script = append(s.script, streamStep{message: m})
+	}
+
+	return s
+}
+
+// RespondError appends an error to the script. Once it is reached, every
+// following Recv call returns it.
+func (s *Stream[Req, Res]) RespondError(err error) *Stream[Req, Res] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.script = append(s.script, streamStep{err: err})
+	return s
+}
+
+// RespondEOF appends the end of the stream to the script. Streams also
+// return io.EOF once their script is exhausted.
+func (s *Stream[Req, Res]) RespondEOF() *Stream[Req, Res] {
+	return s.RespondError(io.EOF)
+}
+
+// WithHeader sets the header metadata returned by the stream.
+func (s *Stream[Req, Res]) WithHeader(md metadata.MD) *Stream[Req, Res] {
+	s.header = md
+	return s
+}
+
+// WithTrailer sets the trailer metadata returned by the stream.
+func (s *Stream[Req, Res]) WithTrailer(md metadata.MD) *Stream[Req, Res] {
+	s.trailer = md
+	return s
+}
+
+// Sent returns all messages sent through the stream by the code under test.
+func (s *Stream[Req, Res]) Sent() []*Req {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Req{}, s.sent...)
+}
+
+// SendClosed tells if the code under test closed the sending side of the
+// stream.
+func (s *Stream[Req, Res]) SendClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sendClosed
+}
+
+// Send records the message sent by the code under test.
+func (s *Stream[Req, Res]) Send(m *Req) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sendClosed {
+		return ErrStreamClosed
+	}
+
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+// Recv returns the next scripted message or error.
+func (s *Stream[Req, Res]) Recv() (*Res, error) {
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next >= len(s.script) {
+		return nil, io.EOF
+	}
+
+	step := s.script[s.next]
+	if step.err != nil {
+		// Errors terminate the stream, so they are returned again by
+		// any following call.
+		return nil, step.err
+	}
+
+	s.next++
+	m, _ := step.message.(*Res)
+	return m, nil
+}
+
+// CloseAndRecv closes the sending side of the stream and returns the next
+// scripted message or error.
+func (s *Stream[Req, Res]) CloseAndRecv() (*Res, error) {
+	if err := s.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return s.Recv()
+}
+
+// Header returns the header metadata set by WithHeader.
+func (s *Stream[Req, Res]) Header() (metadata.MD, error) {
+	return s.header, nil
+}
+
+// Trailer returns the trailer metadata set by WithTrailer.
+func (s *Stream[Req, Res]) Trailer() metadata.MD {
+	return s.trailer
+}
+
+// CloseSend closes the sending side of the stream.
+func (s *Stream[Req, Res]) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sendClosed = true
+	return nil
+}
+
+// Context returns the context of the stream.
+func (s *Stream[Req, Res]) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg is the untyped version of Send.
+func (s *Stream[Req, Res]) SendMsg(m interface{}) error {
+	req, ok := m.(*Req)
+	if !ok {
+		return errors.New("mocks: unexpected message type sent through stream")
+	}
+
+	return s.Send(req)
+}
+
+// RecvMsg is the untyped version of Recv, copying the received message
+// into m.
+func (s *Stream[Req, Res]) RecvMsg(m interface{}) error {
+	res, err := s.Recv()
+	if err != nil {
+		return err
+	}
+
+	return copyMessage(m, res)
+}
+
+// copyMessage copies src into the message pointed by dst.
+func copyMessage(dst, src interface{}) error {
+	if d, ok := dst.(proto.Message); ok {
+		if s, ok := src.(proto.Message); ok {
+			proto.Reset(d)
+			proto.Merge(d, s)
+			return nil
+		}
+	}
+
+	d := reflect.ValueOf(dst)
+	s := reflect.ValueOf(src)
+	if d.Kind() != reflect.Ptr || s.Kind() != reflect.Ptr || d.Type() != s.Type() {
+		return errors.New("mocks: unexpected message type received from stream")
+	}
+
+	d.Elem().Set(s.Elem())
+	return nil
+}
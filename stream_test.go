@@ -0,0 +1,126 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	greeter_mock "github.com/somatech1/mocks/internal/greeter/mock"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("should mock server-streaming method with scripted messages", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		stream := NewStream[wrapperspb.StringValue, wrapperspb.StringValue](ctx).
+			Respond(wrapperspb.String("First"), wrapperspb.String("Second")).
+			RespondEOF()
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().SayHelloStream,
+			Times:  1,
+			Return: stream,
+		})
+
+		c := mock.Client()
+		s, err := c.SayHelloStream(ctx, wrapperspb.String("Hello World"))
+		a.NoError(err)
+
+		first, err := s.Recv()
+		a.NoError(err)
+		a.Equal("First", first.GetValue())
+
+		second, err := s.Recv()
+		a.NoError(err)
+		a.Equal("Second", second.GetValue())
+
+		_, err = s.Recv()
+		a.ErrorIs(err, io.EOF)
+	})
+
+	t.Run("should record messages sent through client-streaming method", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		stream := NewStream[wrapperspb.StringValue, wrapperspb.StringValue](ctx).
+			Respond(wrapperspb.String("Mocked Output"))
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().CollectHellos,
+			Times:  1,
+			Return: stream,
+		})
+
+		c := mock.Client()
+		s, err := c.CollectHellos(ctx)
+		a.NoError(err)
+
+		a.NoError(s.Send(wrapperspb.String("Hello")))
+		a.NoError(s.Send(wrapperspb.String("World")))
+
+		output, err := s.CloseAndRecv()
+		a.NoError(err)
+		a.Equal("Mocked Output", output.GetValue())
+
+		a.True(stream.SendClosed())
+		a.Len(stream.Sent(), 2)
+		a.Equal("World", stream.Sent()[1].GetValue())
+		a.ErrorIs(s.Send(wrapperspb.String("Too late")), ErrStreamClosed)
+	})
+
+	t.Run("should return scripted error from bidi-streaming method", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			greeter_mock.NewMockGreeterClient,
+		)
+
+		expectedError := errors.New("mocked error")
+		stream := NewStream[wrapperspb.StringValue, wrapperspb.StringValue](ctx).
+			Respond(wrapperspb.String("Mocked Output")).
+			RespondError(expectedError)
+
+		mock.Mock(&MockOptions{
+			Ctx:    ctx,
+			Call:   mock.Recorder().Chat,
+			Times:  1,
+			Return: stream,
+		})
+
+		c := mock.Client()
+		s, err := c.Chat(ctx)
+		a.NoError(err)
+		a.NoError(s.Send(wrapperspb.String("Hello World")))
+
+		output, err := s.Recv()
+		a.NoError(err)
+		a.Equal("Mocked Output", output.GetValue())
+
+		_, err = s.Recv()
+		a.ErrorIs(err, expectedError)
+
+		// errors terminate the stream
+		_, err = s.Recv()
+		a.ErrorIs(err, expectedError)
+		a.Equal("Hello World", stream.Sent()[0].GetValue())
+	})
+}
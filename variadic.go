@@ -0,0 +1,158 @@
+package mocks
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+// variadicMatcher matches the variadic arguments of a call as a whole, as
+// the slice received by the method.
+type variadicMatcher struct {
+	desc  string
+	match func(tail reflect.Value) bool
+
+	// typ is the type of the variadic parameter, when known, so that single
+	// variadic arguments, which gomock tries to match first, are told apart
+	// from the tail.
+	typ reflect.Type
+}
+
+// VariadicAny matches any variadic arguments, including none.
+func VariadicAny() gomock.Matcher {
+	return &variadicMatcher{
+		desc: "any variadic arguments",
+		match: func(reflect.Value) bool {
+			return true
+		},
+	}
+}
+
+// VariadicLen matches exactly n variadic arguments, whatever their values.
+func VariadicLen(n int) gomock.Matcher {
+	return &variadicMatcher{
+		desc: fmt.Sprintf("%d variadic argument(s)", n),
+		match: func(tail reflect.Value) bool {
+			return tail.Len() == n
+		},
+	}
+}
+
+// VariadicContains matches variadic arguments holding all the values, in
+// any order, among others. Values can also be matchers.
+func VariadicContains(values ...interface{}) gomock.Matcher {
+	matchers := make([]gomock.Matcher, len(values))
+	for i, v := range values {
+		matchers[i] = toMatcher(v)
+	}
+
+	return &variadicMatcher{
+		desc: fmt.Sprintf("variadic arguments containing %v", matchers),
+		match: func(tail reflect.Value) bool {
+			for _, m := range matchers {
+				if !containsMatch(tail, m) {
+					return false
+				}
+			}
+
+			return true
+		},
+	}
+}
+
+// VariadicMatching matches the variadic arguments, as a slice, with m.
+//
+// Example:
+//
+//	Variadic: mocks.VariadicMatching(gomock.Len(2))
+func VariadicMatching(m gomock.Matcher) gomock.Matcher {
+	return &variadicMatcher{
+		desc: "variadic arguments that " + m.String(),
+		match: func(tail reflect.Value) bool {
+			return m.Matches(tail.Interface())
+		},
+	}
+}
+
+func (m *variadicMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() || v.Kind() != reflect.Slice || (m.typ != nil && v.Type() != m.typ) {
+		return false
+	}
+
+	return m.match(v)
+}
+
+func (m *variadicMatcher) String() string {
+	return m.desc
+}
+
+// forType returns a copy of the matcher only matching tails of type typ.
+func (m *variadicMatcher) forType(typ reflect.Type) *variadicMatcher {
+	c := *m
+	c.typ = typ
+	return &c
+}
+
+// variadicInput adds the variadic arguments set by MockOptions.Variadic to
+// the fixed ones of a call to a recorder. A slice holds the variadic
+// arguments, matched one by one, while a matcher matches all of them as a
+// whole.
+func variadicInput(
+	fixed []reflect.Value,
+	call reflect.Value,
+	variadic interface{},
+	methodType reflect.Type,
+) []reflect.Value {
+	ct := call.Type()
+	if !ct.IsVariadic() {
+		panic("Variadic can only be used with variadic methods")
+	}
+
+	if len(fixed) != ct.NumIn()-1 {
+		panic(fmt.Sprintf(
+			"Input must hold the %d fixed argument(s) after the context when Variadic is set, got %d",
+			ct.NumIn()-2, len(fixed)-1,
+		))
+	}
+
+	if m, ok := variadic.(gomock.Matcher); ok {
+		vm, ok := m.(*variadicMatcher)
+		if !ok {
+			vm = VariadicMatching(m).(*variadicMatcher)
+		}
+
+		if methodType != nil {
+			vm = vm.forType(methodType.In(methodType.NumIn() - 1))
+		}
+
+		return append(fixed, reflect.ValueOf(vm))
+	}
+
+	v := reflect.ValueOf(variadic)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		panic(fmt.Sprintf("Variadic must be a slice of arguments or a matcher, got %T", variadic))
+	}
+
+	return append(fixed, getValuesFromSliceOrArray(v)...)
+}
+
+// containsMatch tells if an element of the slice matches m.
+func containsMatch(slice reflect.Value, m gomock.Matcher) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if m.Matches(slice.Index(i).Interface()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toMatcher(v interface{}) gomock.Matcher {
+	if m, ok := v.(gomock.Matcher); ok {
+		return m
+	}
+
+	return gomock.Eq(v)
+}
@@ -0,0 +1,201 @@
+package mocks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	example_mock "github.com/somatech1/mocks/internal/example/mock"
+)
+
+func TestVariadic(t *testing.T) {
+	t.Run("should match the fixed arguments along with any variadic ones", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    "id",
+			Variadic: VariadicAny(),
+			Times:    3,
+			Return:   1,
+		})
+
+		c := mock.Client()
+		for _, options := range [][]string{nil, {"a"}, {"a", "b", "c"}} {
+			out, err := c.GetWithVariadic(ctx, "id", options...)
+			a.NoError(err)
+			a.Equal(1, out)
+		}
+	})
+
+	t.Run("should match the number of variadic arguments", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+		reporter := &fakeReporter{}
+
+		mock := NewWithCtrl(
+			gomock.NewController(reporter),
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    "id",
+			Variadic: VariadicLen(2),
+			Times:    1,
+			Return:   2,
+		})
+
+		out, err := mock.Client().GetWithVariadic(ctx, "id", "a", "b")
+		a.NoError(err)
+		a.Equal(2, out)
+
+		run(func() {
+			_, _ = mock.Client().GetWithVariadic(ctx, "id", "a")
+		})
+
+		failures := reporter.finish()
+		a.Contains(failures, "Unexpected call")
+		a.Contains(failures, "2 variadic argument(s)")
+	})
+
+	t.Run("should match variadic arguments containing values", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    "id",
+			Variadic: VariadicContains("verbose"),
+			Times:    1,
+			Return:   1,
+		}).Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    "id",
+			Variadic: VariadicAny(),
+			Times:    1,
+			Return:   0,
+		})
+
+		out, err := mock.Client().GetWithVariadic(ctx, "id", "a", "verbose")
+		a.NoError(err)
+		a.Equal(1, out)
+
+		out, err = mock.Client().GetWithVariadic(ctx, "id", "verbose")
+		a.NoError(err)
+		a.Equal(0, out)
+	})
+
+	t.Run("should match variadic arguments one by one when given as a slice", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Input:    "id",
+			Variadic: []string{"a", "b"},
+			Times:    1,
+			Return:   2,
+		})
+
+		out, err := mock.Client().GetWithVariadic(ctx, "id", "a", "b")
+		a.NoError(err)
+		a.Equal(2, out)
+	})
+
+	t.Run("should match variadic arguments with gomock matchers", func(t *testing.T) {
+		ctx := context.TODO()
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		mock.Mock(&MockOptions{
+			Ctx:      ctx,
+			Call:     mock.Recorder().GetWithVariadic,
+			Variadic: VariadicMatching(gomock.Len(2)),
+			Times:    1,
+			Return:   2,
+		})
+
+		out, err := mock.Client().GetWithVariadic(ctx, "any", "ab", "cd")
+		a.NoError(err)
+		a.Equal(2, out)
+	})
+
+	t.Run("should panic when the arguments do not fit the method", func(t *testing.T) {
+		a := assert.New(t)
+
+		mock := New(
+			t,
+			example_mock.NewMockExampleMock,
+		)
+
+		a.PanicsWithValue("Variadic can only be used with variadic methods", func() {
+			mock.Mock(&MockOptions{
+				Call:     mock.Recorder().GetByString,
+				Input:    "x",
+				Variadic: VariadicAny(),
+				Times:    1,
+			})
+		})
+
+		a.PanicsWithValue("Input must hold the 1 fixed argument(s) after the context when Variadic is set, got 2", func() {
+			mock.Mock(&MockOptions{
+				Call:     mock.Recorder().GetWithVariadic,
+				Input:    []interface{}{"id", "a"},
+				Variadic: VariadicAny(),
+				Times:    1,
+			})
+		})
+
+		a.PanicsWithValue("Input has 2 argument(s) after the context, but the method receives 1", func() {
+			mock.Mock(&MockOptions{
+				Call:  mock.Recorder().GetByString,
+				Input: []interface{}{"a", "b"},
+				Times: 1,
+			})
+		})
+	})
+}
+
+func TestVariadicMatcher(t *testing.T) {
+	t.Run("should only match tails of the variadic type", func(t *testing.T) {
+		a := assert.New(t)
+
+		m := VariadicLen(2).(*variadicMatcher)
+		a.True(m.Matches([]string{"a", "b"}))
+		a.False(m.Matches("ab"))
+		a.False(m.Matches(nil))
+
+		typed := m.forType(reflect.TypeOf([]string{}))
+		a.True(typed.Matches([]string{"a", "b"}))
+		a.False(typed.Matches([]int{1, 2}))
+	})
+}